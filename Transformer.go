@@ -1,20 +1,71 @@
 package main
 
-import "errors"
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// default name of the output file when the output is a directory
+const DEFAULT_OUTPUT_NAME = "api"
 
 type Transformer struct {
-	Input       string
-	Output      string
-	ContentFrom ContentSource
-	LangType 	LanguageType
-	JsonContent string
-	MdContent   string
+	Input         string
+	Output        string
+	ContentFrom   ContentSource
+	LangType      LanguageType
+	Format        OutputFormat
+	TemplateDir   string
+	JsonContent   string
+	OutputContent string
+
+	// languages rendered in a single run, LangType alone is rendered when empty
+	Languages []LanguageType
+	// whether to link the documents of the other languages at the top of each document
+	LanguageSwitcher bool
+	// rendered content per language
+	OutputContents map[LanguageType]string
+	// json file of spec text translations keyed by language and JSON pointer
+	TranslationCatalog string
+
+	// single output file, or a split output directory with an index page, a page per tag and component pages
+	Layout OutputLayout
+	// one file per component instead of a single components file in the split layout
+	SplitComponents bool
+	// rendered files of the split layout per language, paths are relative to the output directory of the language
+	OutputFiles map[LanguageType][]OutputFile
+	// doc extracted by the last analysis, before localization
+	Doc *Document
+	// output files rewritten by the last write, files whose content did not change are not rewritten
+	WrittenPaths []string
+	// specs merged into a single doc instead of the input, with the title of the merged doc
+	MergeSources []MergeSource
+	MergeTitle   string
+	// operations to document, the components they do not reference are pruned
+	Filter *OperationFilter
+	// whether to list every deprecated operation, parameter and property in a deprecations section
+	DeprecationReport bool
+	// languages of the code samples added to every operation, samples written in the spec take precedence
+	CodeSamples []SampleLanguage
+	// whether to add a curl command to every operation, the same as a curl code sample
+	CurlExamples bool
+	// whether to add a components overview with class diagrams of the components, a diagram per tag when the
+	// components outnumber DiagramLimit
+	ComponentDiagram bool
+	DiagramLimit     int
 
 	contentGetter ContentGetter
 	analyzer      Analyzer
+	renderer      Renderer
 }
 
 func (t *Transformer) GetContent() error {
+	// merged specs are read through their own getter, whatever the input is
+	if len(t.MergeSources) > 0 {
+		t.contentGetter = NewMergeContentGetter(NewSpecMerger(t.MergeTitle), t.MergeSources)
+	}
 	if t.contentGetter == nil {
 		t.contentGetter = NewSwaggerContentGetter(t.Input, t.ContentFrom)
 	}
@@ -31,26 +82,278 @@ func (t *Transformer) Analyze() error {
 	if t.analyzer == nil {
 		t.analyzer = NewSwaggerAnalyzer(ENGLISH)
 	}
+	if t.Layout == SPLIT_LAYOUT && (t.Format != MARKDOWN_FORMAT || t.TemplateDir != "") {
+		return errors.New("the split layout only supports the built-in markdown format")
+	}
+	if t.renderer == nil && t.Layout == SINGLE_LAYOUT {
+		renderer, err := t.newRenderer()
+		if err != nil {
+			return err
+		}
+		t.renderer = renderer
+	}
 
 	if len(t.JsonContent) == 0 {
 		return errors.New("empty json content")
 	}
 
-	result, err := t.analyzer.Analyze(t.JsonContent)
+	// the doc is extracted once, then rendered in every language
+	t.analyzer.(*SwaggerAnalyzer).SetFilter(t.Filter)
+	doc, err := t.analyzer.Extract(t.JsonContent)
+	if err != nil {
+		return err
+	}
+	t.Doc = doc
+	if t.DeprecationReport {
+		doc.Deprecations = doc.FindDeprecations()
+	}
+	if languages := t.sampleLanguages(); len(languages) > 0 {
+		NewSampleBuilder(doc.Model).AddSamples(doc, languages)
+	}
+	if t.ComponentDiagram {
+		doc.ComponentDiagrams = NewComponentDiagramBuilder(t.DiagramLimit).Build(doc)
+	}
+	if t.TranslationCatalog != "" {
+		catalog, err := LoadTranslationCatalog(t.TranslationCatalog)
+		if err != nil {
+			return err
+		}
+		doc.Translations.Merge(catalog)
+	}
+	languages := t.languages()
+	if t.Layout == SPLIT_LAYOUT {
+		return t.analyzeFiles(doc, languages)
+	}
+	t.OutputContents = make(map[LanguageType]string)
+	for index, lang := range languages {
+		renderer := t.renderer
+		if index > 0 || lang != t.LangType {
+			if renderer, err = t.newRendererFor(lang); err != nil {
+				return err
+			}
+		}
+		result, err := renderer.Render(doc.Localize(lang))
+		if err != nil {
+			return err
+		}
+		t.OutputContents[lang] = result
+	}
+
+	if t.LanguageSwitcher && len(languages) > 1 {
+		for _, lang := range languages {
+			switcher := t.FormatLanguageSwitcher(lang)
+			t.OutputContents[lang] = AddLanguageSwitcher(t.Format, t.OutputContents[lang], switcher)
+		}
+	}
+	t.OutputContent = t.OutputContents[languages[0]]
+	return nil
+}
+
+// languages of the code samples, curl first when the curl examples are asked for
+func (t *Transformer) sampleLanguages() []SampleLanguage {
+	if !t.CurlExamples {
+		return t.CodeSamples
+	}
+	for _, language := range t.CodeSamples {
+		if language == CURL_SAMPLE {
+			return t.CodeSamples
+		}
+	}
+	return append([]SampleLanguage{CURL_SAMPLE}, t.CodeSamples...)
+}
+
+// render the files of the split layout in every language
+func (t *Transformer) analyzeFiles(doc *Document, languages []LanguageType) error {
+	t.OutputFiles = make(map[LanguageType][]OutputFile)
+	for index, lang := range languages {
+		analyzer := t.analyzer.(*SwaggerAnalyzer)
+		if index > 0 || lang != t.LangType {
+			var err error
+			if analyzer, err = newSwaggerAnalyzer(lang); err != nil {
+				return err
+			}
+		}
+		files, err := NewSplitRenderer(analyzer, t.SplitComponents).RenderFiles(doc.Localize(lang))
+		if err != nil {
+			return err
+		}
+		t.OutputFiles[lang] = files
+	}
+
+	if t.LanguageSwitcher && len(languages) > 1 {
+		for _, lang := range languages {
+			for index, file := range t.OutputFiles[lang] {
+				switcher := t.formatFileLanguageSwitcher(lang, file.Path)
+				t.OutputFiles[lang][index].Content = AddLanguageSwitcher(t.Format, file.Content, switcher)
+			}
+		}
+	}
+	t.OutputContent = t.OutputFiles[languages[0]][0].Content
+	return nil
+}
+
+// format links to the same file in the output directories of every language
+func (t *Transformer) formatFileLanguageSwitcher(current LanguageType, path string) []LanguageLink {
+	links := make([]LanguageLink, 0)
+	for _, lang := range t.languages() {
+		href := filepath.ToSlash(filepath.Join("..", string(lang), path))
+		links = append(links, LanguageLink{Lang: lang, Href: href, Current: lang == current})
+	}
+	return links
+}
+
+// languages to render
+func (t *Transformer) languages() []LanguageType {
+	if len(t.Languages) == 0 {
+		return []LanguageType{t.LangType}
+	}
+	return t.Languages
+}
+
+// create the renderer of the output format, user templates take precedence over the built-in layout
+func (t *Transformer) newRenderer() (Renderer, error) {
+	return t.newRendererOf(t.analyzer.(*SwaggerAnalyzer))
+}
+
+// create the renderer of the output format in a given language
+func (t *Transformer) newRendererFor(lang LanguageType) (Renderer, error) {
+	analyzer, err := newSwaggerAnalyzer(lang)
 	if err != nil {
+		return nil, err
+	}
+	return t.newRendererOf(analyzer)
+}
+
+// create the renderer of the output format with the terms of an analyzer, templates only produce markdown
+func (t *Transformer) newRendererOf(analyzer *SwaggerAnalyzer) (Renderer, error) {
+	if t.TemplateDir != "" {
+		if t.Format != MARKDOWN_FORMAT {
+			return nil, errors.New("templates only support the markdown format")
+		}
+		return NewTemplateRenderer(analyzer.terms, t.TemplateDir)
+	}
+	return NewRenderer(t.Format, analyzer)
+}
+
+// format links to the documents of every language, the current one is not linked
+func (t *Transformer) FormatLanguageSwitcher(current LanguageType) []LanguageLink {
+	links := make([]LanguageLink, 0)
+	for _, lang := range t.languages() {
+		link := LanguageLink{Lang: lang, Current: lang == current}
+		link.Href = filepath.Base(t.OutputPathFor(lang))
+		links = append(links, link)
+	}
+	return links
+}
+
+// write the rendered content to the output files, a directory output gets a default file name
+func (t *Transformer) WriteToOutput() error {
+	t.WrittenPaths = make([]string, 0)
+	if t.Layout == SPLIT_LAYOUT {
+		return t.writeFiles()
+	}
+	if len(t.OutputContents) == 0 {
+		return t.writeFile(t.OutputPath(), t.OutputContent)
+	}
+	for _, lang := range t.languages() {
+		if err := t.writeFile(t.OutputPathFor(lang), t.OutputContents[lang]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write an output file unless it already holds the content
+func (t *Transformer) writeFile(path string, content string) error {
+	if existing, err := ioutil.ReadFile(path); err == nil && string(existing) == content {
+		return nil
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
 		return err
 	}
-	t.MdContent = result
+	t.WrittenPaths = append(t.WrittenPaths, path)
+	return nil
+}
+
+// write the files of the split layout, creating the output directories
+func (t *Transformer) writeFiles() error {
+	for _, lang := range t.languages() {
+		outputDir := t.OutputDirFor(lang)
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return err
+		}
+		for _, file := range t.OutputFiles[lang] {
+			if err := t.writeFile(filepath.Join(outputDir, file.Path), file.Content); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
-func (t *Transformer) WriteToOutput() {
+// output directory of a language in the split layout, e.g. out/zh when several languages are rendered
+func (t *Transformer) OutputDirFor(lang LanguageType) string {
+	if len(t.languages()) < 2 {
+		return t.Output
+	}
+	return filepath.Join(t.Output, string(lang))
+}
+
+// path of the output file
+func (t *Transformer) OutputPath() string {
+	output := t.Output
+	if info, err := os.Stat(output); (err == nil && info.IsDir()) || strings.HasSuffix(output, "/") {
+		output = filepath.Join(output, DEFAULT_OUTPUT_NAME+t.Format.Extension())
+	}
+	return output
+}
+
+// path of the output file of a language, e.g. api.zh.md when several languages are rendered
+func (t *Transformer) OutputPathFor(lang LanguageType) string {
+	output := t.OutputPath()
+	if len(t.languages()) < 2 {
+		return output
+	}
+	extension := filepath.Ext(output)
+	return strings.TrimSuffix(output, extension) + "." + string(lang) + extension
+}
 
+// paths of all output files
+func (t *Transformer) OutputPaths() []string {
+	paths := make([]string, 0)
+	for _, lang := range t.languages() {
+		if t.Layout == SPLIT_LAYOUT {
+			for _, file := range t.OutputFiles[lang] {
+				paths = append(paths, filepath.Join(t.OutputDirFor(lang), file.Path))
+			}
+			continue
+		}
+		paths = append(paths, t.OutputPathFor(lang))
+	}
+	return paths
 }
 
-func NewTransformer(input string, output string, contentSource ContentSource, langType LanguageType) *Transformer {
-	transformer := &Transformer{Input:input, Output:output, LangType:langType}
+// get content and analyze it, without writing to output
+func (t *Transformer) Render() error {
+	if err := t.GetContent(); err != nil {
+		return err
+	}
+	return t.Analyze()
+}
+
+// run the whole pipeline: get content, analyze and write to output
+func (t *Transformer) Run() error {
+	if err := t.Render(); err != nil {
+		return err
+	}
+	return t.WriteToOutput()
+}
+
+func NewTransformer(input string, output string, contentSource ContentSource, langType LanguageType,
+	format OutputFormat) *Transformer {
+	transformer := &Transformer{Input: input, Output: output, ContentFrom: contentSource,
+		LangType: langType, Format: format}
 	transformer.contentGetter = NewSwaggerContentGetter(input, contentSource)
 	transformer.analyzer = NewSwaggerAnalyzer(langType)
 	return transformer
-}
\ No newline at end of file
+}
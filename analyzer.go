@@ -4,16 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"os"
+	"sort"
 	"strings"
+	"unicode"
 )
 
-type LanguageType int
-
 const (
-	CHINESE LanguageType = 0
-	ENGLISH LanguageType = 1
-
 	TYPE = "Type"
 	DESCRIPTION = "Description"
 	SCHEMA = "Schema"
@@ -23,60 +19,93 @@ const (
 	PROPERTY_TYPE = "Property Type"
 	REQUIRED = "Required"
 	EXAMPLE = "Example"
-	TRUE = "True"
-	FALSE = "False"
 )
 
-var parameterTableHeader = []string{"Type", "Name", "Description", "Schema"}
-var responseTableHeader = []string{"HTTP Code", "Description", "Schema"}
-var componentTableHeader = []string{"Property Name","Property Type", "Required", "Example"}
+// table headers, the keys of which are localized by LocalizeHeader
+var parameterTableHeader = []string{TYPE, NAME, DESCRIPTION, SCHEMA}
+var responseTableHeader = []string{HTTP_CODE, DESCRIPTION, SCHEMA}
+var componentTableHeader = []string{PROPERTY_NAME, PROPERTY_TYPE, REQUIRED, DESCRIPTION, EXAMPLE}
 
 type Analyzer interface {
 	Analyze(string) (string, error)
+	Extract(string) (*Document, error)
 }
 
 type SwaggerAnalyzer struct {
 	content map[string]string	// content
 	terms map[string]string		// terms associated with language settings
 	generator *MdGenerator		// markdown format generator
+	filter *OperationFilter		// operations and components extracted
 }
 
 // set language of the SwaggerAnalyzer
 func (analyzer *SwaggerAnalyzer) SetLang(lang LanguageType) error {
-	configFile := ""
-	if lang == CHINESE {
-		configFile = "zh_config.json"
-	} else if lang == ENGLISH {
-		configFile = "en_config.json"
-	}
-
-	fin, err := os.Open(configFile)
-	defer fin.Close()
+	terms, err := defaultLanguageRegistry.Resolve(lang)
 	if err != nil {
 		return err
 	}
-	json.NewDecoder(fin).Decode(&analyzer.terms)
+	analyzer.terms = terms
+	if analyzer.generator != nil {
+		analyzer.generator.NoContent = terms["no_content"]
+	}
 	return nil
 }
 
+// set the filter of the operations extracted by the SwaggerAnalyzer
+func (analyzer *SwaggerAnalyzer) SetFilter(filter *OperationFilter) {
+	analyzer.filter = filter
+}
+
 // the main entrance of analysis
 func (analyzer *SwaggerAnalyzer) Analyze(jsonInput string) (string, error) {
+	doc, err := analyzer.Extract(jsonInput)
+	if err != nil {
+		return "", err
+	}
+	return analyzer.Render(doc)
+}
+
+// extract the format independent content of a swagger json doc
+func (analyzer *SwaggerAnalyzer) Extract(jsonInput string) (*Document, error) {
+	model := &Model{}
+	if err := json.Unmarshal([]byte(jsonInput), model); err != nil {
+		return nil, err
+	}
+
+	// tags keep their position in the spec, filtering may remove the tags before them
+	for index := range model.Tags {
+		model.Tags[index].Index = index
+	}
+	// filtering happens before extraction, so every renderer sees the same subset
+	analyzer.filter.Apply(model)
+	doc := &Document{Model: model}
+	doc.Components = analyzer.ExtractComponents(model)
+	doc.Apis = analyzer.ExtractPaths(model)
+	doc.Translations = ExtractTranslations(model)
+	return doc, nil
+}
+
+// render an extracted doc as markdown
+func (analyzer *SwaggerAnalyzer) Render(doc *Document) (string, error) {
 	if analyzer.generator == nil {
 		analyzer.generator = NewMdGenerator()
+		analyzer.generator.NoContent = analyzer.terms["no_content"]
 	}
 
-	model := Model{}
-	json.Unmarshal([]byte(jsonInput), &model)
-	title := analyzer.generator.GetHeader(model.Info.Title, H1, INDENT_0)
-	overviewContent := analyzer.AnalyzeOverview(&model)
-	componentsContent := analyzer.AnalyzeComponents(&model)
-	pathsContent, pathsErr := analyzer.AnalyzePaths(model)
-	if pathsErr != nil {
-		return "", pathsErr
+	title := analyzer.generator.GetHeader(doc.Model.Info.Title, H1, INDENT_0)
+	overviewContent := analyzer.AnalyzeOverview(doc.Model)
+	componentsContent := analyzer.formatComponentsSection(doc.Components)
+	if len(doc.ComponentDiagrams) > 0 {
+		componentsContent = analyzer.FormatComponentDiagrams(doc.ComponentDiagrams) + "\n" + componentsContent
 	}
+	pathsContent := analyzer.formatPathsSection(doc.Apis)
 
-	return fmt.Sprintf("%s\n%s\n%s\n%s",
-		title, overviewContent, componentsContent, pathsContent), nil
+	content := fmt.Sprintf("%s\n%s\n%s\n%s",
+		title, overviewContent, componentsContent, pathsContent)
+	if len(doc.Deprecations) > 0 {
+		content += "\n" + analyzer.FormatDeprecations(doc.Deprecations)
+	}
+	return content, nil
 }
 
 // format info section in swagger json doc
@@ -86,8 +115,8 @@ func (analyzer *SwaggerAnalyzer) FormatInfo(swaggerModel *Model) string {
 
 	contactHeader := analyzer.generator.GetHeader(analyzer.terms["contact"] + "\n", H3, INDENT_0)
 	infoContent += contactHeader
-	for key, value := range swaggerModel.Info.Contact {
-		currentLine := fmt.Sprintf("%s : %s\n", key, value)
+	for _, key := range sortedKeys(swaggerModel.Info.Contact) {
+		currentLine := fmt.Sprintf("%s : %s\n", key, swaggerModel.Info.Contact[key])
 		currentListItem := analyzer.generator.GetListItem(currentLine, INDENT_0)
 		infoContent += currentListItem
 	}
@@ -95,8 +124,8 @@ func (analyzer *SwaggerAnalyzer) FormatInfo(swaggerModel *Model) string {
 
 	licenseHeader := analyzer.generator.GetHeader(analyzer.terms["license"] + "\n", H3, INDENT_0)
 	infoContent += licenseHeader
-	for key, value := range swaggerModel.Info.License {
-		currentLine := fmt.Sprintf("%s : %s\n", key, value)
+	for _, key := range sortedKeys(swaggerModel.Info.License) {
+		currentLine := fmt.Sprintf("%s : %s\n", key, swaggerModel.Info.License[key])
 		currentListItem := analyzer.generator.GetListItem(currentLine, INDENT_0)
 		infoContent += currentListItem
 	}
@@ -116,10 +145,12 @@ func (analyzer *SwaggerAnalyzer) FormatServers(swaggerModel *Model) string {
 	serversContent += "\n"
 
 	for index, server := range swaggerModel.Servers {
-		currentServerHeader := analyzer.generator.GetListItem(fmt.Sprintf("Server-%d\n", index), INDENT_0)
-		currentServerUrl := analyzer.generator.GetListItem(fmt.Sprintf("url : %s\n", server.Url), INDENT_1)
-		currentServerDesc := analyzer.generator.GetListItem(fmt.Sprintf("description : %s\n",
-			server.Description), INDENT_1)
+		currentServerHeader := analyzer.generator.GetListItem(
+			fmt.Sprintf("%s\n", LocalizeServerName(analyzer.terms, index)), INDENT_0)
+		currentServerUrl := analyzer.generator.GetListItem(fmt.Sprintf("%s : %s\n",
+			analyzer.terms["url"], server.Url), INDENT_1)
+		currentServerDesc := analyzer.generator.GetListItem(fmt.Sprintf("%s : %s\n",
+			analyzer.terms["description"], server.Description), INDENT_1)
 		serversContent += currentServerHeader
 		serversContent += currentServerUrl
 		serversContent += currentServerDesc
@@ -155,8 +186,13 @@ func (analyzer *SwaggerAnalyzer) AnalyzeOverview(swaggerModel *Model) string {
 	return overviewContent
 }
 
+// analyze the components part
 func (analyzer *SwaggerAnalyzer) AnalyzeComponents(swaggerModel *Model) string {
-	components := analyzer.ExtractComponents(swaggerModel)
+	return analyzer.formatComponentsSection(analyzer.ExtractComponents(swaggerModel))
+}
+
+// format the components section from extracted components
+func (analyzer *SwaggerAnalyzer) formatComponentsSection(components []Component) string {
 	componentsContent := fmt.Sprintf("%s\n",
 		analyzer.generator.GetHeader(analyzer.terms["components"], H2, INDENT_0))
 
@@ -166,32 +202,36 @@ func (analyzer *SwaggerAnalyzer) AnalyzeComponents(swaggerModel *Model) string {
 
 // analyze the paths part
 func (analyzer *SwaggerAnalyzer) AnalyzePaths(swaggerModel Model) (string, error) {
+	return analyzer.formatPathsSection(analyzer.ExtractPaths(&swaggerModel)), nil
+}
+
+// format the paths section from extracted APIs
+func (analyzer *SwaggerAnalyzer) formatPathsSection(apis []Api) string {
 	pathsContent := make([]string, 0)
-	pathsJson := swaggerModel.Paths
 
 	pathsHeader := analyzer.generator.GetHeader(analyzer.terms["paths"], H2, INDENT_0)
 	pathsContent = append(pathsContent, pathsHeader)
 
-	apiIndex := 1
-	for apiPath, methods := range pathsJson {
-		apis := analyzer.ExtractAPIs(apiPath, methods.(map[string]interface{}))
-		for _, api := range apis {
-			apiInMd := analyzer.FormatAPI(apiIndex, api)
-			pathsContent = append(pathsContent, apiInMd)
-			apiIndex++
-		}
+	for index, api := range apis {
+		apiInMd := analyzer.FormatAPI(index+1, api)
+		pathsContent = append(pathsContent, apiInMd)
 	}
 
-	finalPathsContent := analyzer.compact(pathsContent)
-	return finalPathsContent, nil
+	return analyzer.compact(pathsContent)
 }
 
 // format an API
 func (analyzer *SwaggerAnalyzer) FormatAPI(apiIndex int, api Api) string {
 	apiContent := ""
-	boldDescription := analyzer.generator.GetBoldLine(api.OperationId)
-	description := analyzer.generator.GetItalicLine(boldDescription)
-	apiContent += fmt.Sprintf("%d. %s\n\n", apiIndex, description)
+	boldTitle := analyzer.generator.GetBoldLine(api.Title())
+	title := FormatMarkdownDeprecated(analyzer.terms, analyzer.generator.GetItalicLine(boldTitle), api.Deprecation)
+	apiContent += fmt.Sprintf("%d. %s\n\n", apiIndex, title)
+	if api.Description != "" {
+		apiContent += fmt.Sprintf("%s\n\n", analyzer.generator.GetParagraph(api.Description, INDENT_1))
+	}
+	operationId := analyzer.generator.GetSingleLineCode(api.OperationId, INDENT_0)
+	apiContent += fmt.Sprintf("%s\n\n", analyzer.generator.GetListItem(
+		analyzer.terms["operation_id"] + " : " + operationId, INDENT_1))
 
 	codePath := analyzer.generator.GetMultiLineCode(fmt.Sprintf("%s %s",
 		strings.ToUpper(api.Method), api.Path), INDENT_1)
@@ -203,12 +243,13 @@ func (analyzer *SwaggerAnalyzer) FormatAPI(apiIndex int, api Api) string {
 		for _, parameter := range api.Parameters {
 			currentLine := TableLine{Content: make(map[string]string)}
 			currentLine.Set(TYPE, parameter.In)
-			currentLine.Set(NAME, parameter.Name)
-			currentLine.Set(DESCRIPTION, parameter.Description)
+			currentLine.Set(NAME, FormatMarkdownDeprecated(analyzer.terms, parameter.Name, parameter.Deprecation))
+			currentLine.Set(DESCRIPTION, escapeMarkdownCell(parameter.Description))
 			currentLine.Set(SCHEMA, parameter.Type)
 			pTableLines = append(pTableLines, currentLine)
 		}
-		parameterTable := analyzer.generator.GetTable(parameterTableHeader, pTableLines, INDENT_1)
+		parameterTable := analyzer.generator.GetLabeledTable(parameterTableHeader,
+			LocalizeHeader(analyzer.terms, parameterTableHeader), pTableLines, INDENT_1)
 		apiContent += fmt.Sprintf("%s\n%s\n", parameterHeader, parameterTable)
 	}
 
@@ -218,14 +259,23 @@ func (analyzer *SwaggerAnalyzer) FormatAPI(apiIndex int, api Api) string {
 		for _, response := range api.Responses {
 			currentLine := TableLine{Content: make(map[string]string)}
 			currentLine.Set(HTTP_CODE, response.StatusCode)
-			currentLine.Set(DESCRIPTION, response.Description)
-			currentLine.Set(SCHEMA, response.Schema)
+			currentLine.Set(DESCRIPTION, escapeMarkdownCell(response.Description))
+			currentLine.Set(SCHEMA, LocalizeSchema(analyzer.terms, response.Schema))
 			rTableLines = append(rTableLines, currentLine)
 		}
-		responseTable := analyzer.generator.GetTable(responseTableHeader, rTableLines, INDENT_1)
+		responseTable := analyzer.generator.GetLabeledTable(responseTableHeader,
+			LocalizeHeader(analyzer.terms, responseTableHeader), rTableLines, INDENT_1)
 		apiContent += fmt.Sprintf("%s\n%s\n", responseHeader, responseTable)
 	}
 
+	if len(api.CodeSamples) > 0 {
+		apiContent += fmt.Sprintf("%s\n", analyzer.generator.GetHeader(analyzer.terms["code_samples"], H4, INDENT_1))
+		for _, sample := range api.CodeSamples {
+			apiContent += fmt.Sprintf("%s\n\n%s\n", analyzer.generator.GetListItem(sample.Label, INDENT_1),
+				analyzer.generator.GetSourceCode(sample.Source, sample.Lang, INDENT_1))
+		}
+	}
+
 	TagHeader := analyzer.generator.GetHeader(analyzer.terms["tags"], H4, INDENT_1)
 	apiContent += fmt.Sprintf("%s\n", TagHeader)
 	for _, tag := range api.Tags {
@@ -233,9 +283,44 @@ func (analyzer *SwaggerAnalyzer) FormatAPI(apiIndex int, api Api) string {
 		apiContent += fmt.Sprintf("%s\n", currentListItem)
 	}
 
+	if api.Origin != "" {
+		originHeader := analyzer.generator.GetHeader(analyzer.terms["origin"], H4, INDENT_1)
+		apiContent += fmt.Sprintf("%s\n%s\n", originHeader, analyzer.generator.GetListItem(api.Origin, INDENT_1))
+	}
+
 	return apiContent
 }
 
+// format the components overview section, a mermaid class diagram per tag when the doc has several
+func (analyzer *SwaggerAnalyzer) FormatComponentDiagrams(diagrams []ComponentDiagram) string {
+	content := analyzer.generator.GetHeader(analyzer.terms["components_overview"], H2, INDENT_0) + "\n\n"
+	for _, diagram := range diagrams {
+		if diagram.Tag != "" {
+			content += analyzer.generator.GetHeader(diagram.Tag, H3, INDENT_0) + "\n\n"
+		}
+		content += analyzer.generator.GetSourceCode(diagram.Source, "mermaid", INDENT_0) + "\n\n"
+		if diagram.Omitted > 0 {
+			content += FormatOmittedComponents(analyzer.terms, diagram.Omitted) + "\n\n"
+		}
+	}
+	return content
+}
+
+// format the deprecations section as a table
+func (analyzer *SwaggerAnalyzer) FormatDeprecations(elements []DeprecatedElement) string {
+	header := analyzer.generator.GetHeader(analyzer.terms["deprecations"], H2, INDENT_0)
+	tableLines := make([]TableLine, 0, len(elements))
+	for _, row := range deprecationRows(analyzer.terms, elements, markdownEscaper.Replace) {
+		currentLine := TableLine{Content: make(map[string]string)}
+		for index, cell := range row {
+			currentLine.Set(deprecationTableHeader[index], cell)
+		}
+		tableLines = append(tableLines, currentLine)
+	}
+	return fmt.Sprintf("%s\n\n%s", header, analyzer.generator.GetLabeledTable(deprecationTableHeader,
+		LocalizeHeader(analyzer.terms, deprecationTableHeader), tableLines, INDENT_0))
+}
+
 // format a slice of components
 func (analyzer *SwaggerAnalyzer) FormatComponents(components []Component) string {
 	componentsContent := ""
@@ -248,81 +333,142 @@ func (analyzer *SwaggerAnalyzer) FormatComponents(components []Component) string
 // format a single component
 func (analyzer *SwaggerAnalyzer) FormatComponent(component *Component) string {
 	componentContent := fmt.Sprintf("%s\n", analyzer.generator.GetListItem(component.Name, INDENT_0))
+	if component.Description != "" {
+		componentContent += fmt.Sprintf("\n%s\n\n", analyzer.generator.GetParagraph(component.Description, INDENT_1))
+	}
 	typeInCode := analyzer.generator.GetSingleLineCode(component.Type, INDENT_0)
-	componentContent += fmt.Sprintf("%s\n", analyzer.generator.GetListItem("type : " + typeInCode, INDENT_1))
-	componentContent += fmt.Sprintf("%s\n\n", analyzer.generator.GetListItem("properties", INDENT_1))
+	componentContent += fmt.Sprintf("%s\n", analyzer.generator.GetListItem(
+		analyzer.terms["type"] + " : " + typeInCode, INDENT_1))
+	componentContent += fmt.Sprintf("%s\n\n", analyzer.generator.GetListItem(analyzer.terms["properties"], INDENT_1))
 	tableLines := make([]TableLine, 0, len(component.Properties))
 	for _, property := range component.Properties {
 		currentLine := TableLine{Content: make(map[string]string)}
-		currentLine.Set(PROPERTY_NAME, property.Name)
-		currentLine.Set(PROPERTY_TYPE, property.Type)
-		if property.Required {
-			currentLine.Set(REQUIRED, TRUE)
-		} else {
-			currentLine.Set(REQUIRED, FALSE)
-		}
-		currentLine.Set(EXAMPLE, property.Example)
+		currentLine.Set(PROPERTY_NAME, FormatMarkdownDeprecated(analyzer.terms, property.Name, property.Deprecation))
+		currentLine.Set(PROPERTY_TYPE, analyzer.formatPropertyType(property.Type))
+		currentLine.Set(REQUIRED, LocalizeBool(analyzer.terms, property.Required))
+		currentLine.Set(DESCRIPTION, escapeMarkdownCell(property.Description))
+		currentLine.Set(EXAMPLE, escapeMarkdownCell(property.Example))
 		tableLines = append(tableLines, currentLine)
 	}
 	componentContent += fmt.Sprintf("%s\n",
-		analyzer.generator.GetTable(componentTableHeader, tableLines, INDENT_2))
+		analyzer.generator.GetLabeledTable(componentTableHeader,
+			LocalizeHeader(analyzer.terms, componentTableHeader), tableLines, INDENT_2))
 	componentContent += fmt.Sprintf("%s\n\n",
-		analyzer.generator.GetListItem("JSON representation", INDENT_1))
+		analyzer.generator.GetListItem(analyzer.terms["json_representation"], INDENT_1))
 	componentContent += fmt.Sprintf("%s\n",
 		analyzer.generator.GetMultiLineCode(component.Code, INDENT_2))
 	return componentContent
 }
 
+// format a property type in italic, escaping the angle brackets of array types
+func (analyzer *SwaggerAnalyzer) formatPropertyType(propertyType string) string {
+	escaped := strings.NewReplacer("<", "\\<", ">", "\\>").Replace(propertyType)
+	return analyzer.generator.GetItalicLine(escaped)
+}
+
 // extract components
 func (analyzer *SwaggerAnalyzer) ExtractComponents(swaggerModel *Model) []Component {
 	components := make([]Component, 0, len(swaggerModel.Components.Schemas))
 
 	for componentName, component := range swaggerModel.Components.Schemas {
-		currentComponent := Component{Name: componentName, Type: component.(map[string]interface{})["type"].(string)}
-		required := make(map[string]bool)
-		requiredFields := component.(map[string]interface{})["required"].([]interface{})
-		for _, requiredField := range requiredFields {
-			required[requiredField.(string)] = true
-		}
-
-		properties := component.(map[string]interface{})["properties"].(map[string]interface{})
-		currentProperties := make([]Property, 0, len(properties))
-		for propertyName, property := range properties {
-			currentProperty := Property{Name: propertyName,
-			Type: property.(map[string]interface{})["type"].(string)}
-			if example, ok := property.(map[string]interface{})["example"]; ok {
-				currentProperty.Example = fmt.Sprintf("%v", example)
-			} else {
-				currentProperty.Example = "/"
-			}
-			if isRequired, ok := required[propertyName]; ok {
-				currentProperty.Required = isRequired
-			}
-			if currentProperty.Type == "array" {
-				arrayType := property.(map[string]interface{})["items"].(map[string]interface{})["type"].(string)
-				currentProperty.Type = fmt.Sprintf("array\\<%s\\>", arrayType)
-			}
-			currentProperty.Type = analyzer.generator.GetItalicLine(currentProperty.Type)
-			currentProperties = append(currentProperties, currentProperty)
+		currentComponent := Component{Name: componentName, Type: extractSchemaType(component)}
+		if description, ok := component.(map[string]interface{})["description"].(string); ok {
+			currentComponent.Description = description
 		}
 		code, err := json.MarshalIndent(component, "", "    ")
 		if err != nil {
 			panic(err)
 		}
 		currentComponent.Code = string(code)
-		currentComponent.Properties = currentProperties
+		currentComponent.Properties = extractProperties(component)
 		components = append(components, currentComponent)
 	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
 	return components
 }
 
+// extract the properties of an object schema, ordered by name
+func extractProperties(schema interface{}) []Property {
+	fields, _ := schema.(map[string]interface{})
+	required := make(map[string]bool)
+	requiredFields, _ := fields["required"].([]interface{})
+	for _, requiredField := range requiredFields {
+		if name, ok := requiredField.(string); ok {
+			required[name] = true
+		}
+	}
+
+	properties, _ := fields["properties"].(map[string]interface{})
+	currentProperties := make([]Property, 0, len(properties))
+	for propertyName, property := range properties {
+		currentProperty := Property{Name: propertyName, Type: extractSchemaType(property)}
+		if example, ok := property.(map[string]interface{})["example"]; ok {
+			currentProperty.Example = fmt.Sprintf("%v", example)
+		} else {
+			currentProperty.Example = "/"
+		}
+		if description, ok := property.(map[string]interface{})["description"].(string); ok {
+			currentProperty.Description = description
+		}
+		if isRequired, ok := required[propertyName]; ok {
+			currentProperty.Required = isRequired
+		}
+		currentProperty.Enum = extractEnum(property)
+		currentProperty.Deprecation = extractDeprecation(property)
+		if currentProperty.Type == "array" {
+			arrayType := extractSchemaType(property.(map[string]interface{})["items"])
+			currentProperty.Type = fmt.Sprintf("array<%s>", arrayType)
+		}
+		currentProperties = append(currentProperties, currentProperty)
+	}
+	sort.Slice(currentProperties, func(i, j int) bool {
+		return currentProperties[i].Name < currentProperties[j].Name
+	})
+	return currentProperties
+}
+
+// extract APIs of every path, ordered by path and method
+func (analyzer *SwaggerAnalyzer) ExtractPaths(swaggerModel *Model) []Api {
+	apiPaths := make([]string, 0, len(swaggerModel.Paths))
+	for apiPath := range swaggerModel.Paths {
+		apiPaths = append(apiPaths, apiPath)
+	}
+	sort.Strings(apiPaths)
+
+	apis := make([]Api, 0)
+	for _, apiPath := range apiPaths {
+		methods, ok := swaggerModel.Paths[apiPath].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		apis = append(apis, analyzer.extractAPIs(apiPath, methods, swaggerModel.Components.Parameters)...)
+	}
+	return apis
+}
+
 // extract APIs from a given method formatted in Json
 func (analyzer *SwaggerAnalyzer) ExtractAPIs(apiPath string, methods map[string]interface{}) []Api {
+	return analyzer.extractAPIs(apiPath, methods, nil)
+}
+
+// extract the operations of a path item, other keys of the path item like its parameters or servers are skipped and
+// parameter references are resolved against the component parameters
+func (analyzer *SwaggerAnalyzer) extractAPIs(apiPath string, methods map[string]interface{},
+	componentParameters map[string]interface{}) []Api {
 	apis := make([]Api, 0, len(methods))
 
 	for methodName, value := range methods {
+		if _, ok := value.(map[string]interface{}); !ok || !operationMethods[methodName] {
+			continue
+		}
 		currentApi := Api{}
-		responses := value.(map[string]interface{})["responses"].(map[string]interface{})
+		// responses are optional since 3.1
+		responses, ok := value.(map[string]interface{})["responses"].(map[string]interface{})
+		if !ok {
+			responses = map[string]interface{}{}
+		}
 		currentApi.Responses = make([]Response, 0, len(responses))
 		currentApi.Path = apiPath
 		currentApi.Method = methodName
@@ -332,30 +478,57 @@ func (analyzer *SwaggerAnalyzer) ExtractAPIs(apiPath string, methods map[string]
 		}
 		currentApi.ResponseInJson = string(responseJson)
 		for statusCode, returnInfo := range responses {
-			currentResponse := Response{StatusCode: statusCode,
-			Description: returnInfo.(map[string]interface{})["description"].(string)}
-			if content, ok := returnInfo.(map[string]interface{})["content"]; ok {
-				contentJson := content.(map[string]interface{})
-				for _, value := range contentJson {
-					schema := value.(map[string]interface{})["schema"]
-					currentResponse.Schema = schema.(map[string]interface{})["type"].(string)
+			currentResponse := Response{StatusCode: statusCode}
+			currentResponse.Description, _ = returnInfo.(map[string]interface{})["description"].(string)
+			if content, ok := returnInfo.(map[string]interface{})["content"].(map[string]interface{}); ok {
+				if mediaType, ok := preferredMediaType(content); ok {
+					currentResponse.Schema = extractSchemaType(mediaType["schema"])
 				}
-			} else {
-				currentResponse.Schema = "No schema"
 			}
 			currentApi.Responses = append(currentApi.Responses, currentResponse)
 		}
-		currentApi.OperationId = value.(map[string]interface{})["operationId"].(string)
+		sort.Slice(currentApi.Responses, func(i, j int) bool {
+			return currentApi.Responses[i].StatusCode < currentApi.Responses[j].StatusCode
+		})
+		if operationId, ok := value.(map[string]interface{})["operationId"].(string); ok && operationId != "" {
+			currentApi.OperationId = operationId
+		} else {
+			currentApi.OperationId = GenerateOperationId(methodName, apiPath)
+		}
+		if summary, ok := value.(map[string]interface{})["summary"].(string); ok {
+			currentApi.Summary = summary
+		}
+		if description, ok := value.(map[string]interface{})["description"].(string); ok {
+			currentApi.Description = description
+		}
+		if origin, ok := value.(map[string]interface{})[ORIGIN_EXTENSION].(string); ok {
+			currentApi.Origin = origin
+		}
+		currentApi.Deprecation = extractDeprecation(value)
+		if security, ok := value.(map[string]interface{})["security"].([]interface{}); ok {
+			currentApi.Security = extractSecurity(security)
+		}
+		currentApi.CodeSamples = extractCodeSamples(value)
 
 		if parameters, ok := value.(map[string]interface{})["parameters"].([]interface{}); ok {
 			for _, parameter := range parameters {
-				currentParameter := Parameter{
-					Description: parameter.(map[string]interface{})["description"].(string),
-					Name: parameter.(map[string]interface{})["name"].(string),
-					Type: parameter.(map[string]interface{})["schema"].(map[string]interface{})["type"].(string),
-					In: parameter.(map[string]interface{})["in"].(string)}
-				if example, ok := parameter.(map[string]interface{})["schema"].(map[string]interface{})["example"]; ok {
-					currentParameter.Example = example.(string)
+				parameter = resolveParameter(parameter, componentParameters)
+				if _, ok := parameter.(map[string]interface{}); !ok {
+					parameter = map[string]interface{}{}
+				}
+				currentParameter := Parameter{Type: extractSchemaType(parameter.(map[string]interface{})["schema"])}
+				currentParameter.Name, _ = parameter.(map[string]interface{})["name"].(string)
+				currentParameter.In, _ = parameter.(map[string]interface{})["in"].(string)
+				currentParameter.Description, _ = parameter.(map[string]interface{})["description"].(string)
+				if required, ok := parameter.(map[string]interface{})["required"].(bool); ok {
+					currentParameter.Required = required
+				}
+				currentParameter.Enum = extractEnum(parameter.(map[string]interface{})["schema"])
+				currentParameter.Deprecation = extractDeprecation(parameter)
+				if example, ok := parameter.(map[string]interface{})["example"]; ok {
+					currentParameter.Example = fmt.Sprintf("%v", example)
+				} else if example, ok := extractSchemaExample(parameter.(map[string]interface{})["schema"]); ok {
+					currentParameter.Example = fmt.Sprintf("%v", example)
 				} else {
 					currentParameter.Example = ""
 				}
@@ -363,9 +536,11 @@ func (analyzer *SwaggerAnalyzer) ExtractAPIs(apiPath string, methods map[string]
 			}
 		}
 
-		tags := value.(map[string]interface{})["tags"].([]interface{})
+		tags, _ := value.(map[string]interface{})["tags"].([]interface{})
 		for _, tag := range tags {
-			currentApi.Tags = append(currentApi.Tags, tag.(string))
+			if name, ok := tag.(string); ok {
+				currentApi.Tags = append(currentApi.Tags, name)
+			}
 		}
 
 		requestBody := value.(map[string]interface{})["requestBody"]
@@ -376,9 +551,102 @@ func (analyzer *SwaggerAnalyzer) ExtractAPIs(apiPath string, methods map[string]
 		currentApi.RequestBodyInJson = string(requestBodyInJson)
 		apis = append(apis, currentApi)
 	}
+	sort.Slice(apis, func(i, j int) bool {
+		return apis[i].Method < apis[j].Method
+	})
 	return apis
 }
 
+// generate the operation id of an operation which has none from its method and path,
+// e.g. get /pets/{petId} becomes getPetsPetId
+func GenerateOperationId(method string, apiPath string) string {
+	operationId := strings.ToLower(method)
+	words := strings.FieldsFunc(apiPath, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		runes := []rune(word)
+		operationId += string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+	return operationId
+}
+
+// extract security requirements, an empty slice when the API requires no authentication
+func extractSecurity(requirements []interface{}) []map[string][]string {
+	security := make([]map[string][]string, 0, len(requirements))
+	for _, requirement := range requirements {
+		schemes := make(map[string][]string)
+		for name, scopes := range requirement.(map[string]interface{}) {
+			schemes[name] = make([]string, 0)
+			for _, scope := range scopes.([]interface{}) {
+				schemes[name] = append(schemes[name], scope.(string))
+			}
+		}
+		security = append(security, schemes)
+	}
+	return security
+}
+
+// the media type of a content map documented as the schema, application/json when there is one, the first in sorted
+// order otherwise
+func preferredMediaType(content map[string]interface{}) (map[string]interface{}, bool) {
+	if mediaType, ok := content["application/json"].(map[string]interface{}); ok {
+		return mediaType, true
+	}
+	for _, name := range sortedObjectKeys(content) {
+		if mediaType, ok := content[name].(map[string]interface{}); ok {
+			return mediaType, true
+		}
+	}
+	return nil, false
+}
+
+// a parameter, or the component parameter it references
+func resolveParameter(parameter interface{}, componentParameters map[string]interface{}) interface{} {
+	object, _ := parameter.(map[string]interface{})
+	ref, _ := object["$ref"].(string)
+	if !strings.HasPrefix(ref, "#/components/parameters/") {
+		return parameter
+	}
+	if resolved, ok := componentParameters[strings.TrimPrefix(ref, "#/components/parameters/")]; ok {
+		return resolved
+	}
+	return parameter
+}
+
+// extract the type of a schema, the name of the component it references when untyped, object when neither
+func extractSchemaType(schema interface{}) string {
+	fields, _ := schema.(map[string]interface{})
+	if schemaType, ok := fields["type"].(string); ok && schemaType != "" {
+		return schemaType
+	}
+	if ref := schemaRef(fields); ref != "" {
+		return ref
+	}
+	return "object"
+}
+
+// extract the example of a schema
+func extractSchemaExample(schema interface{}) (interface{}, bool) {
+	fields, _ := schema.(map[string]interface{})
+	example, ok := fields["example"]
+	return example, ok
+}
+
+// extract the allowed values of a schema, nil when it has no enum
+func extractEnum(schema interface{}) []string {
+	fields, _ := schema.(map[string]interface{})
+	values, ok := fields["enum"].([]interface{})
+	if !ok {
+		return nil
+	}
+	enum := make([]string, 0, len(values))
+	for _, value := range values {
+		enum = append(enum, fmt.Sprintf("%v", value))
+	}
+	return enum
+}
+
 // compact means removing empty & useless line contents
 func (analyzer *SwaggerAnalyzer) compact(content []string) string {
 	compactedContent := ""
@@ -392,12 +660,20 @@ func (analyzer *SwaggerAnalyzer) compact(content []string) string {
 
 // factory for SwaggerAnalyzer
 func NewSwaggerAnalyzer(lang LanguageType) *SwaggerAnalyzer {
+	analyzer, err := newSwaggerAnalyzer(lang)
+	if err != nil {
+		log.Fatalf("language setting error: %v", err)
+	}
+	return analyzer
+}
+
+// factory for SwaggerAnalyzer, returning language setting errors
+func newSwaggerAnalyzer(lang LanguageType) (*SwaggerAnalyzer, error) {
 	analyzer := &SwaggerAnalyzer{}
 	analyzer.content = make(map[string]string)
 	analyzer.generator = NewMdGenerator()
-	err := analyzer.SetLang(lang)
-	if err != nil {
-		log.Fatal("language setting error, only support zh or en now")
+	if err := analyzer.SetLang(lang); err != nil {
+		return nil, err
 	}
-	return analyzer
-}
\ No newline at end of file
+	return analyzer, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -236,4 +237,127 @@ func TestSwaggerAnalyzer_AnalyzeComponents(t *testing.T) {
 		componentsContent := analyzer.AnalyzeComponents(&model)
 		fmt.Println(componentsContent)
 	}
-}
\ No newline at end of file
+}
+
+// test ExtractAPIs in SwaggerAnalyzer with operations missing an operationId
+func TestSwaggerAnalyzer_ExtractAPIsWithoutOperationId(t *testing.T) {
+	t.Log("Test swagger analyzer - GenerateOperationId")
+	{
+		for expected, apiPath := range map[string]string{"getPetsPetId": "/pets/{petId}", "get": "/",
+			"getStoreOrderItems": "/store/order-items"} {
+			if operationId := GenerateOperationId("GET", apiPath); operationId != expected {
+				t.Errorf("expected %s, got %s", expected, operationId)
+			}
+		}
+	}
+
+	t.Log("Test swagger analyzer - ExtractAPIs falls back to a generated operationId")
+	{
+		methods := make(map[string]interface{})
+		json.Unmarshal([]byte(`{"delete": {"summary": "Delete a pet", "tags": ["pets"],
+			"responses": {"204": {"description": "deleted"}}}}`), &methods)
+		apis := NewSwaggerAnalyzer(ENGLISH).ExtractAPIs("/pets/{petId}", methods)
+		if apis[0].OperationId != "deletePetsPetId" || apis[0].Title() != "Delete a pet" {
+			t.Errorf("unexpected api %v", apis[0])
+		}
+	}
+}
+
+// test ExtractPaths in SwaggerAnalyzer with path level keys, parameter references and operations without responses
+func TestSwaggerAnalyzer_ExtractPathsOfValidSpecs(t *testing.T) {
+	t.Log("Test swagger analyzer - ExtractPaths skips the keys of a path item which are not operations")
+	{
+		doc, err := NewSwaggerAnalyzer(ENGLISH).Extract(`{"openapi": "3.0.0", "info": {"title": "T", "version": "1.0.0"},
+			"paths": {"/pets/{id}": {"summary": "pet", "description": "a pet", "servers": [{"url": "/"}],
+				"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+				"get": {"operationId": "getPet", "responses": {"200": {"description": "ok"}}}}}}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(doc.Apis) != 1 || doc.Apis[0].Method != "get" || doc.Apis[0].OperationId != "getPet" {
+			t.Errorf("unexpected apis %v", doc.Apis)
+		}
+	}
+
+	t.Log("Test swagger analyzer - ExtractPaths resolves parameter references")
+	{
+		doc, err := NewSwaggerAnalyzer(ENGLISH).Extract(`{"openapi": "3.0.0", "info": {"title": "T", "version": "1.0.0"},
+			"paths": {"/pets": {"get": {"parameters": [{"$ref": "#/components/parameters/Limit"}, {"$ref": "#/missing"}],
+				"responses": {"200": {"description": "ok"}}}}},
+			"components": {"parameters": {"Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}}}}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parameters := doc.Apis[0].Parameters
+		if len(parameters) != 2 || parameters[0].Name != "limit" || parameters[0].In != "query" ||
+			parameters[0].Type != "integer" {
+			t.Errorf("unexpected parameters %v", parameters)
+		}
+	}
+
+	t.Log("Test swagger analyzer - ExtractPaths treats missing responses as empty")
+	{
+		doc, err := NewSwaggerAnalyzer(ENGLISH).Extract(`{"openapi": "3.1.0", "info": {"title": "T", "version": "1.0.0"},
+			"paths": {"/pets": {"get": {"operationId": "listPets"}}}}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(doc.Apis) != 1 || len(doc.Apis[0].Responses) != 0 || doc.Apis[0].ResponseInJson != "{}" {
+			t.Errorf("unexpected apis %v", doc.Apis)
+		}
+		if _, err := NewSwaggerAnalyzer(ENGLISH).Render(doc); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// test the description cells of the markdown tables in SwaggerAnalyzer
+func TestSwaggerAnalyzer_EscapeTableCells(t *testing.T) {
+	t.Log("Test swagger analyzer - Render escapes pipes and line breaks of descriptions")
+	{
+		analyzer := NewSwaggerAnalyzer(ENGLISH)
+		doc, err := analyzer.Extract(`{"openapi": "3.0.0", "info": {"title": "T", "version": "1.0.0"},
+			"paths": {"/pets": {"get": {"operationId": "listPets",
+				"parameters": [{"name": "limit", "in": "query", "description": "a|b\nsecond line", "schema": {"type": "integer"}}],
+				"responses": {"200": {"description": "ok|done"}}}}},
+			"components": {"schemas": {"Pet": {"type": "object",
+				"properties": {"name": {"type": "string", "description": "first line\nsecond|line\n"}}}}}}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := analyzer.Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"|query|limit|a\\|b<br>second line|integer|", "|200|ok\\|done|",
+			"|first line<br>second\\|line|"} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("expected %q in:\n%s", expected, result)
+			}
+		}
+	}
+}
+
+// test the media type documented as the schema of a response in SwaggerAnalyzer
+func TestSwaggerAnalyzer_ResponseMediaType(t *testing.T) {
+	t.Log("Test swagger analyzer - prefer application/json, then the first media type in sorted order")
+	{
+		cases := map[string]string{
+			`{"text/plain": {"schema": {"type": "string"}}, "application/json": {"schema": {"$ref": "#/components/schemas/Pet"}},
+				"application/xml": {"schema": {"type": "object"}}}`: "Pet",
+			`{"text/plain": {"schema": {"type": "string"}}, "application/xml": {"schema": {"type": "object"}}}`: "object",
+		}
+		for content, expected := range cases {
+			for run := 0; run < 10; run++ {
+				doc, err := NewSwaggerAnalyzer(ENGLISH).Extract(`{"openapi": "3.0.0", "info": {"title": "T", "version": "1.0.0"},
+					"paths": {"/pets": {"get": {"responses": {"200": {"description": "ok", "content": ` + content + `}}}}}}`)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if schema := doc.Apis[0].Responses[0].Schema; schema != expected {
+					t.Fatalf("expected %s, got %s", expected, schema)
+				}
+			}
+		}
+	}
+}
@@ -9,6 +9,9 @@ type Parameter struct {
 	Type string
 	In string
 	Example string
+	Required bool
+	Enum []string
+	Deprecation
 }
 
 func (p Parameter) String() string {
@@ -36,8 +39,31 @@ type Api struct {
 	ResponseInJson string
 	RequestBodyInJson string
 	OperationId string
+	Summary     string
+	Description string
 	Parameters  []Parameter
 	Tags        []string
+	Origin      string // title of the spec the API was merged from
+	Deprecation
+	// security requirements of the API, nil when it inherits the security of the doc
+	Security    []map[string][]string
+	// copy-pasteable requests in several languages, e.g. curl
+	CodeSamples []CodeSample
+}
+
+// CodeSample struct, a sample request to an API
+type CodeSample struct {
+	Lang   string // language of the source, e.g. shell
+	Label  string // display name, e.g. curl
+	Source string
+}
+
+// title of an API, its summary or its operation id when it has none
+func (api Api) Title() string {
+	if api.Summary != "" {
+		return api.Summary
+	}
+	return api.OperationId
 }
 
 func (api Api) String() string {
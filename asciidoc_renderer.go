@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type AsciiDocRenderer struct {
+	terms map[string]string // terms associated with language settings
+}
+
+// render an extracted doc as an asciidoc document
+func (renderer *AsciiDocRenderer) Render(doc *Document) (string, error) {
+	content := fmt.Sprintf("= %s\n:toc: left\n:toclevels: 3\n\n", doc.Model.Info.Title)
+	content += renderer.FormatOverview(doc.Model)
+	if len(doc.ComponentDiagrams) > 0 {
+		content += renderer.FormatComponentDiagrams(doc.ComponentDiagrams)
+	}
+	content += renderer.FormatComponents(doc)
+	content += renderer.FormatPaths(doc)
+	if len(doc.Deprecations) > 0 {
+		content += renderer.FormatDeprecations(doc.Deprecations)
+	}
+	return content, nil
+}
+
+// format the overview section, including info, servers and tags
+func (renderer *AsciiDocRenderer) FormatOverview(swaggerModel *Model) string {
+	overview := fmt.Sprintf("[[overview]]\n== %s\n\n", renderer.terms["overview"])
+	overview += fmt.Sprintf("*%s*\n\n", swaggerModel.Info.Description)
+
+	overview += fmt.Sprintf("=== %s\n\n", renderer.terms["contact"])
+	for _, key := range sortedKeys(swaggerModel.Info.Contact) {
+		overview += fmt.Sprintf("* %s : %s\n", key, swaggerModel.Info.Contact[key])
+	}
+	overview += "\n"
+
+	overview += fmt.Sprintf("=== %s\n\n", renderer.terms["license"])
+	for _, key := range sortedKeys(swaggerModel.Info.License) {
+		overview += fmt.Sprintf("* %s : %s\n", key, swaggerModel.Info.License[key])
+	}
+	overview += "\n"
+
+	overview += fmt.Sprintf("=== %s\n\n%s\n\n", renderer.terms["version"], swaggerModel.Info.Version)
+
+	overview += fmt.Sprintf("=== %s\n\n", renderer.terms["servers"])
+	for index, server := range swaggerModel.Servers {
+		overview += fmt.Sprintf("* %s\n** %s : `%s`\n** %s : %s\n", LocalizeServerName(renderer.terms, index),
+			renderer.terms["url"], server.Url, renderer.terms["description"], server.Description)
+	}
+	overview += "\n"
+
+	overview += fmt.Sprintf("=== %s\n\n", renderer.terms["tags"])
+	for _, tag := range swaggerModel.Tags {
+		overview += fmt.Sprintf("%s:: %s\n", tag.Name, tag.Description)
+	}
+	overview += "\n"
+	return overview
+}
+
+// format the components section
+func (renderer *AsciiDocRenderer) FormatComponents(doc *Document) string {
+	content := fmt.Sprintf("[[components]]\n== %s\n\n", renderer.terms["components"])
+	for _, component := range doc.Components {
+		content += renderer.FormatComponent(doc, &component)
+	}
+	return content
+}
+
+// format a single component, with cross references to the components and APIs it relates to
+func (renderer *AsciiDocRenderer) FormatComponent(doc *Document, component *Component) string {
+	content := fmt.Sprintf("[[%s]]\n=== %s\n\n", doc.ComponentAnchor(component.Name), component.Name)
+	if component.Description != "" {
+		content += strings.TrimSpace(component.Description) + "\n\n"
+	}
+	content += fmt.Sprintf("%s : `%s`\n\n", renderer.terms["type"], component.Type)
+
+	rows := make([][]string, 0, len(component.Properties))
+	for _, property := range component.Properties {
+		rows = append(rows, []string{renderer.FormatDeprecated(property.Name, property.Deprecation),
+			fmt.Sprintf("_%s_", property.Type),
+			LocalizeBool(renderer.terms, property.Required), property.Description, property.Example})
+	}
+	content += fmt.Sprintf(".%s\n", renderer.terms["properties"])
+	content += renderer.GetTable(componentTableHeader, rows)
+
+	content += fmt.Sprintf(".%s\n", renderer.terms["json_representation"])
+	content += renderer.GetSourceBlock("json", component.Code)
+
+	if refs := doc.ComponentRefs(*component); len(refs) > 0 {
+		content += renderer.terms["references"] + ": " + renderer.formatComponentXrefs(doc, refs) + "\n\n"
+	}
+	if usages := doc.ComponentUsages(component.Name); len(usages) > 0 {
+		xrefs := make([]string, 0, len(usages))
+		for _, api := range usages {
+			xrefs = append(xrefs, renderer.formatApiXref(doc, api))
+		}
+		content += renderer.terms["used_by"] + ": " + strings.Join(xrefs, ", ") + "\n\n"
+	}
+	return content
+}
+
+// format the paths section
+func (renderer *AsciiDocRenderer) FormatPaths(doc *Document) string {
+	content := fmt.Sprintf("[[paths]]\n== %s\n\n", renderer.terms["paths"])
+	for index, api := range doc.Apis {
+		content += renderer.FormatAPI(doc, index+1, api)
+	}
+	return content
+}
+
+// format an API, with cross references to the components it uses
+func (renderer *AsciiDocRenderer) FormatAPI(doc *Document, apiIndex int, api Api) string {
+	content := fmt.Sprintf("[[%s]]\n=== %d. %s\n\n", doc.ApiAnchor(api), apiIndex,
+		renderer.FormatDeprecated(api.Title(), api.Deprecation))
+	if api.Description != "" {
+		content += strings.TrimSpace(api.Description) + "\n\n"
+	}
+	content += fmt.Sprintf("%s : `%s`\n\n", renderer.terms["operation_id"], api.OperationId)
+	content += renderer.GetSourceBlock("http", fmt.Sprintf("%s %s", strings.ToUpper(api.Method), api.Path))
+
+	if len(api.Parameters) > 0 {
+		rows := make([][]string, 0, len(api.Parameters))
+		for _, parameter := range api.Parameters {
+			rows = append(rows, []string{parameter.In, renderer.FormatDeprecated(parameter.Name, parameter.Deprecation),
+				parameter.Description, parameter.Type})
+		}
+		content += fmt.Sprintf("==== %s\n\n", renderer.terms["parameters"])
+		content += renderer.GetTable(parameterTableHeader, rows)
+	}
+
+	if len(api.Responses) > 0 {
+		rows := make([][]string, 0, len(api.Responses))
+		for _, response := range api.Responses {
+			rows = append(rows, []string{response.StatusCode, response.Description,
+				LocalizeSchema(renderer.terms, response.Schema)})
+		}
+		content += fmt.Sprintf("==== %s\n\n", renderer.terms["responses"])
+		content += renderer.GetTable(responseTableHeader, rows)
+	}
+
+	if len(api.CodeSamples) > 0 {
+		content += fmt.Sprintf("==== %s\n\n", renderer.terms["code_samples"])
+		for _, sample := range api.CodeSamples {
+			content += fmt.Sprintf(".%s\n", sample.Label)
+			content += renderer.GetSourceBlock(sample.Lang, sample.Source)
+		}
+	}
+
+	if refs := doc.ApiComponentRefs(api); len(refs) > 0 {
+		content += fmt.Sprintf("==== %s\n\n", renderer.terms["components"])
+		content += renderer.formatComponentXrefs(doc, refs) + "\n\n"
+	}
+
+	content += fmt.Sprintf("==== %s\n\n", renderer.terms["tags"])
+	for _, tag := range api.Tags {
+		content += fmt.Sprintf("* %s\n", tag)
+	}
+	content += "\n"
+
+	if api.Origin != "" {
+		content += fmt.Sprintf("==== %s\n\n%s\n\n", renderer.terms["origin"], api.Origin)
+	}
+	return content
+}
+
+// format the components overview section, mermaid blocks are drawn by asciidoctor-diagram
+func (renderer *AsciiDocRenderer) FormatComponentDiagrams(diagrams []ComponentDiagram) string {
+	content := fmt.Sprintf("[[components-overview]]\n== %s\n\n", renderer.terms["components_overview"])
+	for _, diagram := range diagrams {
+		if diagram.Tag != "" {
+			content += fmt.Sprintf("=== %s\n\n", diagram.Tag)
+		}
+		content += fmt.Sprintf("[mermaid]\n....\n%s\n....\n\n", diagram.Source)
+		if diagram.Omitted > 0 {
+			content += FormatOmittedComponents(renderer.terms, diagram.Omitted) + "\n\n"
+		}
+	}
+	return content
+}
+
+// format the deprecations section
+func (renderer *AsciiDocRenderer) FormatDeprecations(elements []DeprecatedElement) string {
+	content := fmt.Sprintf("[[deprecations]]\n== %s\n\n", renderer.terms["deprecations"])
+	content += renderer.GetTable(deprecationTableHeader,
+		deprecationRows(renderer.terms, elements, func(cell string) string { return cell }))
+	return content
+}
+
+// strike a name through when deprecated, followed by a badge and the details of the deprecation
+func (renderer *AsciiDocRenderer) FormatDeprecated(name string, deprecation Deprecation) string {
+	if !deprecation.Deprecated {
+		return name
+	}
+	marked := fmt.Sprintf("[line-through]#%s# [.deprecated]*%s*", name, renderer.terms["deprecated"])
+	if details := FormatDeprecationDetails(renderer.terms, deprecation.DeprecationDetails); details != "" {
+		marked += fmt.Sprintf(" (%s)", details)
+	}
+	return marked
+}
+
+// generate a table with a localized header row
+func (renderer *AsciiDocRenderer) GetTable(header []string, rows [][]string) string {
+	cols := strings.TrimSuffix(strings.Repeat("1,", len(header)), ",")
+	table := fmt.Sprintf("[cols=\"%s\", options=\"header\"]\n|===\n", cols)
+	for _, label := range LocalizeHeader(renderer.terms, header) {
+		table += "|" + renderer.escapeCell(label)
+	}
+	table += "\n\n"
+	for _, row := range rows {
+		for _, cell := range row {
+			table += "|" + renderer.escapeCell(cell) + "\n"
+		}
+		table += "\n"
+	}
+	table += "|===\n\n"
+	return table
+}
+
+// generate a source block in a given language
+func (renderer *AsciiDocRenderer) GetSourceBlock(language string, content string) string {
+	return fmt.Sprintf("[source,%s]\n----\n%s\n----\n\n", language, content)
+}
+
+// escape the cell separator in a table cell
+func (renderer *AsciiDocRenderer) escapeCell(cell string) string {
+	return strings.Replace(cell, "|", "\\|", -1)
+}
+
+// format cross references to components
+func (renderer *AsciiDocRenderer) formatComponentXrefs(doc *Document, componentNames []string) string {
+	xrefs := make([]string, 0, len(componentNames))
+	for _, name := range componentNames {
+		xrefs = append(xrefs, fmt.Sprintf("<<%s,%s>>", doc.ComponentAnchor(name), name))
+	}
+	return strings.Join(xrefs, ", ")
+}
+
+// format a cross reference to an API
+func (renderer *AsciiDocRenderer) formatApiXref(doc *Document, api Api) string {
+	return fmt.Sprintf("<<%s,%s %s>>", doc.ApiAnchor(api), strings.ToUpper(api.Method), api.Path)
+}
+
+// factory for AsciiDocRenderer
+func NewAsciiDocRenderer(terms map[string]string) *AsciiDocRenderer {
+	return &AsciiDocRenderer{terms: terms}
+}
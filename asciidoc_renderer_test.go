@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// test GetTable in AsciiDocRenderer
+func TestAsciiDocRenderer_GetTable(t *testing.T) {
+	t.Log("Test asciidoc renderer - GetTable")
+	{
+		renderer := NewAsciiDocRenderer(map[string]string{"name": "名称", "description": "描述"})
+		table := renderer.GetTable([]string{NAME, DESCRIPTION}, [][]string{{"a|b", "c"}})
+		expected := "[cols=\"1,1\", options=\"header\"]\n|===\n|名称|描述\n\n|a\\|b\n|c\n\n|===\n\n"
+		if table != expected {
+			t.Errorf("expected %q, got %q", expected, table)
+		}
+	}
+}
+
+// test Render in AsciiDocRenderer
+func TestAsciiDocRenderer_Render(t *testing.T) {
+	t.Log("Test asciidoc renderer - Render")
+	{
+		analyzer := NewSwaggerAnalyzer(ENGLISH)
+		doc, err := analyzer.Extract(readTestSpec(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := NewAsciiDocRenderer(analyzer.terms).Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{
+			"= Swagger Petstore\n:toc: left\n",
+			"[[component-pet]]\n=== Pet\n",
+			"[[op-get-pets-petid]]\n=== 3. ",
+			"<<component-pet,Pet>>",
+			"Used by: <<op-post-store-orders,POST /store/orders>>",
+			"|_array<string>_\n",
+			"[source,json]\n----\n{\n",
+		} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("expected %q in asciidoc output", expected)
+			}
+		}
+	}
+}
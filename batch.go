@@ -0,0 +1,373 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// base name of the aggregated index of a batch
+	BATCH_INDEX_NAME = "index"
+
+	VERSION = "Version"
+)
+
+// extensions of the specs found in the directories of a batch
+var specExtensions = map[string]bool{".json": true, ".yaml": true, ".yml": true}
+
+// BatchResult struct, the outcome of the conversion of one spec of a batch
+type BatchResult struct {
+	Input       string
+	Output      string
+	OutputPaths []string
+	Title       string
+	Version     string
+	Description string
+	Err         error
+}
+
+// Batch struct, converting many specs in parallel with a bounded pool of workers
+type Batch struct {
+	Inputs    []string
+	OutputDir string
+	Format    OutputFormat // format of the index, the docs follow their transformers
+	Jobs      int          // number of workers
+	// create the transformer of a spec, each worker owns its transformer and analyzer
+	NewTransformer func(input string, output string) *Transformer
+	// called with the result of every converted spec, from the worker which converted it
+	OnResult func(result BatchResult)
+}
+
+// convert every input, results are in the order of the inputs
+func (batch *Batch) Run() []BatchResult {
+	outputs := batch.outputs()
+	results := make([]BatchResult, len(batch.Inputs))
+	indexes := make(chan int)
+	workers := batch.Jobs
+	if workers < 1 {
+		workers = 1
+	}
+
+	group := sync.WaitGroup{}
+	for worker := 0; worker < workers; worker++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for index := range indexes {
+				results[index] = batch.convert(batch.Inputs[index], outputs[index])
+				if batch.OnResult != nil {
+					batch.OnResult(results[index])
+				}
+			}
+		}()
+	}
+	for index := range batch.Inputs {
+		indexes <- index
+	}
+	close(indexes)
+	group.Wait()
+	return results
+}
+
+// convert a spec, a panic of the conversion fails the spec instead of the whole batch
+func (batch *Batch) convert(input string, output string) (result BatchResult) {
+	result = BatchResult{Input: input, Output: output}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.Err = fmt.Errorf("unexpected error converting the spec: %v", recovered)
+		}
+	}()
+
+	if output == filepath.Join(batch.OutputDir, BATCH_INDEX_NAME) {
+		result.Err = errors.New("the output collides with the index of the batch")
+		return result
+	}
+	transformer := batch.NewTransformer(input, output)
+	if transformer.Layout == SPLIT_LAYOUT {
+		result.Err = os.MkdirAll(output, 0755)
+	} else {
+		transformer.Output = output + transformer.Format.Extension()
+		result.Output = transformer.Output
+		result.Err = os.MkdirAll(filepath.Dir(output), 0755)
+	}
+	if result.Err != nil {
+		return result
+	}
+	if result.Err = transformer.Run(); result.Err != nil {
+		return result
+	}
+	result.OutputPaths = transformer.OutputPaths()
+	result.Title = transformer.Doc.Model.Info.Title
+	result.Version = transformer.Doc.Model.Info.Version
+	result.Description = transformer.Doc.Model.Info.Description
+	return result
+}
+
+// output of every input without extension, named after its path relative to the common directory of the inputs,
+// e.g. specs/pets/openapi.json becomes <output dir>/pets/openapi.md
+func (batch *Batch) outputs() []string {
+	paths := make([]string, 0, len(batch.Inputs))
+	for _, input := range batch.Inputs {
+		paths = append(paths, inputPath(input))
+	}
+	common := commonDir(paths)
+
+	outputs := make([]string, 0, len(paths))
+	for _, inputPath := range paths {
+		relative := strings.TrimPrefix(strings.TrimPrefix(inputPath, common), "/")
+		relative = strings.TrimSuffix(relative, path.Ext(relative))
+		outputs = append(outputs, filepath.Join(batch.OutputDir, filepath.FromSlash(relative)))
+	}
+	return outputs
+}
+
+// write the index of a batch in its output directory, returning its path
+func (batch *Batch) WriteIndex(terms map[string]string, results []BatchResult) (string, error) {
+	indexPath := filepath.Join(batch.OutputDir, BATCH_INDEX_NAME+batch.Format.Extension())
+	if err := os.MkdirAll(batch.OutputDir, 0755); err != nil {
+		return "", err
+	}
+	content := NewBatchIndexRenderer(terms).Render(batch.Format, batch.OutputDir, results)
+	return indexPath, ioutil.WriteFile(indexPath, []byte(content), 0644)
+}
+
+// slash separated path of an input, the path of a web url
+func inputPath(input string) string {
+	if DetectContentSource(input) == WEB_SOURCE {
+		input = input[strings.Index(input, "://")+3:]
+		if index := strings.IndexAny(input, "?#"); index >= 0 {
+			input = input[:index]
+		}
+		return path.Clean("/" + input)
+	}
+	absolute, err := filepath.Abs(input)
+	if err != nil {
+		absolute = input
+	}
+	return filepath.ToSlash(absolute)
+}
+
+// longest directory shared by slash separated paths
+func commonDir(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	common := path.Dir(paths[0])
+	for _, current := range paths[1:] {
+		for common != "/" && common != "." && !strings.HasPrefix(current, common+"/") {
+			common = path.Dir(common)
+		}
+	}
+	if common == "/" || common == "." {
+		return ""
+	}
+	return common
+}
+
+// whether an input names several specs, a local directory or a glob, the query of a web url is no glob
+func IsBatchInput(input string) bool {
+	if DetectContentSource(input) == WEB_SOURCE {
+		return false
+	}
+	if strings.ContainsAny(input, "*?[") {
+		return true
+	}
+	info, err := os.Stat(input)
+	return err == nil && info.IsDir()
+}
+
+// expand directories to the json and yaml files they contain and globs to the files they match, web urls are kept
+func ExpandInputs(inputs []string) ([]string, error) {
+	seen := make(map[string]bool)
+	expanded := make([]string, 0)
+	add := func(input string) {
+		if !seen[input] {
+			seen[input] = true
+			expanded = append(expanded, input)
+		}
+	}
+
+	for _, input := range inputs {
+		if DetectContentSource(input) == WEB_SOURCE {
+			add(input)
+			continue
+		}
+		matches := []string{input}
+		if strings.ContainsAny(input, "*?[") {
+			var err error
+			if matches, err = filepath.Glob(input); err != nil {
+				return nil, err
+			}
+		}
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				add(match)
+				continue
+			}
+			files := make([]string, 0)
+			err = filepath.WalkDir(match, func(file string, entry fs.DirEntry, err error) error {
+				if err == nil && !entry.IsDir() && specExtensions[strings.ToLower(filepath.Ext(file))] {
+					files = append(files, file)
+				}
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			sort.Strings(files)
+			for _, file := range files {
+				add(file)
+			}
+		}
+	}
+	if len(expanded) == 0 {
+		return nil, errors.New("no spec matches the inputs")
+	}
+	return expanded, nil
+}
+
+// BatchIndexRenderer struct, rendering the index linking the docs of a batch
+type BatchIndexRenderer struct {
+	terms     map[string]string // terms associated with language settings
+	generator *MdGenerator
+}
+
+// batchIndexEntry struct, a doc linked by the index
+type batchIndexEntry struct {
+	Title       string
+	Href        string // relative to the output directory
+	Version     string
+	Description string
+}
+
+// render the index of the specs converted successfully in a format, links are relative to the output directory
+func (renderer *BatchIndexRenderer) Render(format OutputFormat, outputDir string, results []BatchResult) string {
+	entries := renderer.entries(outputDir, results)
+	switch format {
+	case HTML_FORMAT:
+		return renderer.renderHtml(entries)
+	case ASCIIDOC_FORMAT:
+		return renderer.renderAsciiDoc(entries)
+	case RST_FORMAT:
+		return renderer.renderRst(entries)
+	case CONFLUENCE_FORMAT:
+		return renderer.renderConfluence(entries)
+	default:
+		return renderer.renderMarkdown(entries)
+	}
+}
+
+// docs of the specs converted successfully
+func (renderer *BatchIndexRenderer) entries(outputDir string, results []BatchResult) []batchIndexEntry {
+	entries := make([]batchIndexEntry, 0, len(results))
+	for _, result := range results {
+		if result.Err != nil || len(result.OutputPaths) == 0 {
+			continue
+		}
+		href, err := filepath.Rel(outputDir, result.OutputPaths[0])
+		if err != nil {
+			href = result.OutputPaths[0]
+		}
+		title := result.Title
+		if title == "" {
+			title = result.Input
+		}
+		entries = append(entries, batchIndexEntry{Title: title, Href: filepath.ToSlash(href), Version: result.Version,
+			Description: strings.Replace(result.Description, "\n", " ", -1)})
+	}
+	return entries
+}
+
+// render the index as a markdown table
+func (renderer *BatchIndexRenderer) renderMarkdown(entries []batchIndexEntry) string {
+	content := renderer.generator.GetHeader(renderer.terms["index"], H1, INDENT_0) + "\n\n"
+	header := []string{NAME, VERSION, DESCRIPTION}
+	tableLines := make([]TableLine, 0, len(entries))
+	for _, entry := range entries {
+		currentLine := TableLine{Content: make(map[string]string)}
+		currentLine.Set(NAME, renderer.generator.GetLink(markdownEscaper.Replace(entry.Title), entry.Href))
+		currentLine.Set(VERSION, markdownEscaper.Replace(entry.Version))
+		currentLine.Set(DESCRIPTION, markdownEscaper.Replace(entry.Description))
+		tableLines = append(tableLines, currentLine)
+	}
+	return content + renderer.generator.GetLabeledTable(header, LocalizeHeader(renderer.terms, header),
+		tableLines, INDENT_0)
+}
+
+// render the index as a standalone html page
+func (renderer *BatchIndexRenderer) renderHtml(entries []batchIndexEntry) string {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(entry.Href), html.EscapeString(entry.Title)),
+			html.EscapeString(entry.Version), html.EscapeString(entry.Description)})
+	}
+	title := html.EscapeString(renderer.terms["index"])
+	page := "<!DOCTYPE html>\n<html>\n<head>\n"
+	page += "<meta charset=\"utf-8\">\n"
+	page += "<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n"
+	page += fmt.Sprintf("<title>%s</title>\n", title)
+	page += fmt.Sprintf("<style>%s</style>\n", htmlStyleSheet)
+	page += "</head>\n<body>\n<main class=\"index\">\n"
+	page += fmt.Sprintf("<header>\n<h1>%s</h1>\n</header>\n", title)
+	page += NewHtmlRenderer(renderer.terms).GetTable([]string{NAME, VERSION, DESCRIPTION}, rows)
+	page += "</main>\n</body>\n</html>\n"
+	return page
+}
+
+// render the index as an asciidoc table
+func (renderer *BatchIndexRenderer) renderAsciiDoc(entries []batchIndexEntry) string {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		link := fmt.Sprintf("link:++%s++[%s]", entry.Href, strings.Replace(entry.Title, "]", "\\]", -1))
+		rows = append(rows, []string{link, entry.Version, entry.Description})
+	}
+	content := fmt.Sprintf("= %s\n\n", renderer.terms["index"])
+	return content + NewAsciiDocRenderer(renderer.terms).GetTable([]string{NAME, VERSION, DESCRIPTION}, rows)
+}
+
+// render the index as a restructuredtext list-table
+func (renderer *BatchIndexRenderer) renderRst(entries []batchIndexEntry) string {
+	rstRenderer := NewRstRenderer(renderer.terms)
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		// anonymous hyperlink, titles shared by several docs do not clash
+		link := fmt.Sprintf("`%s <%s>`__", strings.NewReplacer("<", "\\<", ">", "\\>").Replace(rstRenderer.escape(entry.Title)),
+			entry.Href)
+		rows = append(rows, []string{link, rstRenderer.escape(entry.Version), rstRenderer.escape(entry.Description)})
+	}
+	title := rstRenderer.escape(renderer.terms["index"])
+	overline := strings.Repeat(rstHeadingChars[0], displayWidth(title))
+	content := fmt.Sprintf("%s\n%s\n%s\n\n", overline, title, overline)
+	return content + rstRenderer.GetListTable(title, []string{NAME, VERSION, DESCRIPTION}, rows)
+}
+
+// render the index as a confluence storage format table
+func (renderer *BatchIndexRenderer) renderConfluence(entries []batchIndexEntry) string {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(entry.Href), html.EscapeString(entry.Title)),
+			html.EscapeString(entry.Version), html.EscapeString(entry.Description)})
+	}
+	content := fmt.Sprintf("<h1>%s</h1>\n", html.EscapeString(renderer.terms["index"]))
+	return content + NewConfluenceRenderer(renderer.terms).GetTable([]string{NAME, VERSION, DESCRIPTION}, rows)
+}
+
+// factory for BatchIndexRenderer
+func NewBatchIndexRenderer(terms map[string]string) *BatchIndexRenderer {
+	return &BatchIndexRenderer{terms: terms, generator: NewMdGenerator()}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// test Run and WriteIndex in Batch
+func TestBatch_Run(t *testing.T) {
+	specDir := t.TempDir()
+	specs := map[string]string{
+		"pets/openapi.json":   readTestSpec(t),
+		"store/openapi.json":  strings.Replace(readTestSpec(t), "Swagger Petstore", "Store", 1),
+		"broken.json":         `{"openapi": "3.0.0", "paths": "/pets"}`,
+		"orders/openapi.yaml": "openapi: 3.0.0\ninfo:\n  title: Orders\n  version: 1.0.0\npaths: {}\n",
+		"notes.txt":           "not a spec",
+	}
+	for name, content := range specs {
+		os.MkdirAll(filepath.Join(specDir, filepath.Dir(name)), 0755)
+		if err := ioutil.WriteFile(filepath.Join(specDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Log("Test batch - ExpandInputs with a directory")
+	{
+		inputs, err := ExpandInputs([]string{specDir})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(inputs) != 4 {
+			t.Errorf("expected the 3 json files and the yaml file, got %v", inputs)
+		}
+	}
+
+	t.Log("Test batch - IsBatchInput")
+	{
+		cases := map[string]bool{specDir: true, filepath.Join(specDir, "*.json"): true,
+			filepath.Join(specDir, "broken.json"): false, "https://example.com/openapi.json?version=2": false}
+		for input, expected := range cases {
+			if IsBatchInput(input) != expected {
+				t.Errorf("%s: expected %v", input, expected)
+			}
+		}
+	}
+
+	t.Log("Test batch - Run with a worker pool and write the index")
+	{
+		inputs, err := ExpandInputs([]string{filepath.Join(specDir, "*", "openapi.json"), filepath.Join(specDir, "broken.json")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputDir := t.TempDir()
+		batch := &Batch{Inputs: inputs, OutputDir: outputDir, Jobs: 2,
+			NewTransformer: func(input string, output string) *Transformer {
+				return NewTransformer(input, output, LOCAL_SOURCE, ENGLISH, MARKDOWN_FORMAT)
+			}}
+		results := batch.Run()
+
+		if results[0].Err != nil || results[0].Output != filepath.Join(outputDir, "pets", "openapi.md") {
+			t.Errorf("unexpected result %+v", results[0])
+		}
+		if results[1].Err != nil || results[1].Title != "Store" {
+			t.Errorf("unexpected result %+v", results[1])
+		}
+		if results[2].Err == nil {
+			t.Errorf("the broken spec should fail")
+		}
+
+		indexPath, err := batch.WriteIndex(NewSwaggerAnalyzer(ENGLISH).terms, results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		index, err := ioutil.ReadFile(indexPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"|[Swagger Petstore](pets/openapi.md)|1.0.0|", "|[Store](store/openapi.md)|"} {
+			if !strings.Contains(string(index), expected) {
+				t.Errorf("expected %q in the index:\n%s", expected, index)
+			}
+		}
+	}
+
+	t.Log("Test batch - write the index in the output format of the batch")
+	{
+		inputs, err := ExpandInputs([]string{filepath.Join(specDir, "pets", "openapi.json")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputDir := t.TempDir()
+		batch := &Batch{Inputs: inputs, OutputDir: outputDir, Format: HTML_FORMAT, Jobs: 1,
+			NewTransformer: func(input string, output string) *Transformer {
+				return NewTransformer(input, output, LOCAL_SOURCE, ENGLISH, HTML_FORMAT)
+			}}
+		results := batch.Run()
+		if results[0].Err != nil || results[0].Output != filepath.Join(outputDir, "openapi.html") {
+			t.Errorf("unexpected result %+v", results[0])
+		}
+
+		indexPath, err := batch.WriteIndex(NewSwaggerAnalyzer(ENGLISH).terms, results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if indexPath != filepath.Join(outputDir, "index.html") {
+			t.Errorf("unexpected index path %s", indexPath)
+		}
+		index, err := ioutil.ReadFile(indexPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(index), "<td><a href=\"openapi.html\">Swagger Petstore</a></td><td>1.0.0</td>") {
+			t.Errorf("unexpected index:\n%s", index)
+		}
+	}
+
+	t.Log("Test batch - a panic of the conversion fails the spec without blaming it")
+	{
+		batch := &Batch{Inputs: []string{"pets.json"}, OutputDir: t.TempDir(), Jobs: 1,
+			NewTransformer: func(input string, output string) *Transformer {
+				panic("index out of range")
+			}}
+		results := batch.Run()
+		if results[0].Err == nil || results[0].Err.Error() != "unexpected error converting the spec: index out of range" {
+			t.Errorf("unexpected error %v", results[0].Err)
+		}
+	}
+}
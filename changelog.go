@@ -0,0 +1,83 @@
+package main
+
+import "fmt"
+
+const (
+	CHANGE   = "Change"
+	ELEMENT  = "Element"
+	LOCATION = "Location"
+	DETAIL   = "Detail"
+)
+
+var changeTableHeader = []string{CHANGE, ELEMENT, LOCATION, DETAIL}
+
+// ChangelogRenderer struct, rendering the changes between two versions of a spec as markdown
+type ChangelogRenderer struct {
+	terms     map[string]string // terms associated with language settings
+	generator *MdGenerator
+}
+
+// render a changelog with the breaking changes first
+func (renderer *ChangelogRenderer) Render(diff *SpecDiff) string {
+	content := renderer.generator.GetHeader(renderer.terms["changelog"], H1, INDENT_0) + "\n"
+	content += fmt.Sprintf("%s → %s\n\n", diff.Base.Model.Info.Version, diff.Revision.Model.Info.Version)
+	content += renderer.FormatChanges(renderer.terms["breaking_changes"], diff.Breaking())
+	content += renderer.FormatChanges(renderer.terms["non_breaking_changes"], diff.NonBreaking())
+	return content
+}
+
+// render the report of a breaking change check, with the required version bump before the changes
+func (renderer *ChangelogRenderer) RenderReport(report *BreakingReport) string {
+	content := renderer.generator.GetHeader(renderer.terms["changelog"], H1, INDENT_0) + "\n"
+	content += fmt.Sprintf("%s → %s\n\n", report.BaseVersion, report.RevisionVersion)
+	content += renderer.generator.GetListItem(fmt.Sprintf("%s : %s",
+		renderer.terms["version_bump"], report.RequiredBump), INDENT_0) + "\n"
+	if report.SuggestedVersion != "" {
+		content += renderer.generator.GetListItem(fmt.Sprintf("%s : %s",
+			renderer.terms["suggested_version"], report.SuggestedVersion), INDENT_0) + "\n"
+	}
+	content += "\n"
+	content += renderer.FormatChanges(renderer.terms["breaking_changes"], report.Breaking)
+	content += renderer.FormatChanges(renderer.terms["non_breaking_changes"], report.NonBreaking)
+	return content
+}
+
+// format a section of changes as a table
+func (renderer *ChangelogRenderer) FormatChanges(title string, changes []Change) string {
+	content := renderer.generator.GetHeader(title, H2, INDENT_0) + "\n"
+	if len(changes) == 0 {
+		return content + renderer.terms["no_changes"] + "\n\n"
+	}
+
+	tableLines := make([]TableLine, 0, len(changes))
+	for _, change := range changes {
+		currentLine := TableLine{Content: make(map[string]string)}
+		currentLine.Set(CHANGE, renderer.terms[string(change.Kind)])
+		currentLine.Set(ELEMENT, renderer.terms[string(change.Element)])
+		currentLine.Set(LOCATION, markdownEscaper.Replace(change.Location))
+		currentLine.Set(DETAIL, markdownEscaper.Replace(renderer.FormatDetail(change)))
+		tableLines = append(tableLines, currentLine)
+	}
+	return content + renderer.generator.GetLabeledTable(changeTableHeader,
+		LocalizeHeader(renderer.terms, changeTableHeader), tableLines, INDENT_0) + "\n"
+}
+
+// format the changed field of a change, e.g. Type : integer → string
+func (renderer *ChangelogRenderer) FormatDetail(change Change) string {
+	if change.Field == "" {
+		return ""
+	}
+	from, to := change.From, change.To
+	if change.Field == "required" {
+		from, to = LocalizeBool(renderer.terms, from == "true"), LocalizeBool(renderer.terms, to == "true")
+	}
+	if change.Kind == ADDED_CHANGE {
+		return fmt.Sprintf("%s : %s", renderer.terms[change.Field], to)
+	}
+	return fmt.Sprintf("%s : %s → %s", renderer.terms[change.Field], from, to)
+}
+
+// factory for ChangelogRenderer
+func NewChangelogRenderer(terms map[string]string) *ChangelogRenderer {
+	return &ChangelogRenderer{terms: terms, generator: NewMdGenerator()}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type SampleLanguage int
+
+const (
+	CURL_SAMPLE       SampleLanguage = 0
+	GO_SAMPLE         SampleLanguage = 1
+	PYTHON_SAMPLE     SampleLanguage = 2
+	JAVASCRIPT_SAMPLE SampleLanguage = 3
+)
+
+const (
+	// vendor extension of the code samples written in a spec, and its legacy name
+	CODE_SAMPLES_EXTENSION        = "x-codeSamples"
+	LEGACY_CODE_SAMPLES_EXTENSION = "x-code-samples"
+)
+
+// Invalid code sample language, no sample can be generated in it
+var InvalidSampleLanguage = errors.New("invalid code sample language")
+
+// methods having a function of their own in python requests
+var pythonRequestsMethods = map[string]bool{"get": true, "post": true, "put": true, "patch": true, "delete": true,
+	"head": true, "options": true}
+
+// parse the name of a code sample language given on the command line
+func ParseSampleLanguage(name string) (SampleLanguage, error) {
+	switch strings.ToLower(name) {
+	case "curl", "shell":
+		return CURL_SAMPLE, nil
+	case "go", "golang":
+		return GO_SAMPLE, nil
+	case "python", "py":
+		return PYTHON_SAMPLE, nil
+	case "javascript", "js":
+		return JAVASCRIPT_SAMPLE, nil
+	default:
+		return CURL_SAMPLE, InvalidSampleLanguage
+	}
+}
+
+// parse a comma separated list of code sample languages
+func ParseSampleLanguages(list string) ([]SampleLanguage, error) {
+	languages := make([]SampleLanguage, 0)
+	for _, name := range splitList(list) {
+		language, err := ParseSampleLanguage(name)
+		if err != nil {
+			return nil, err
+		}
+		languages = append(languages, language)
+	}
+	return languages, nil
+}
+
+// extract the code samples written in an operation of a spec
+func extractCodeSamples(operation interface{}) []CodeSample {
+	fields, _ := operation.(map[string]interface{})
+	items, ok := fields[CODE_SAMPLES_EXTENSION].([]interface{})
+	if !ok {
+		items, _ = fields[LEGACY_CODE_SAMPLES_EXTENSION].([]interface{})
+	}
+	samples := make([]CodeSample, 0, len(items))
+	for _, item := range items {
+		item, _ := item.(map[string]interface{})
+		sample := CodeSample{}
+		sample.Lang, _ = item["lang"].(string)
+		sample.Label, _ = item["label"].(string)
+		sample.Source, _ = item["source"].(string)
+		if sample.Label == "" {
+			sample.Label = sample.Lang
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+// build the code sample of an API in a language
+func (builder *SampleBuilder) Build(api Api, language SampleLanguage) CodeSample {
+	switch language {
+	case GO_SAMPLE:
+		return builder.Go(api)
+	case PYTHON_SAMPLE:
+		return builder.Python(api)
+	case JAVASCRIPT_SAMPLE:
+		return builder.JavaScript(api)
+	default:
+		return builder.Curl(api)
+	}
+}
+
+// build the go net/http program sending the request of an API
+func (builder *SampleBuilder) Go(api Api) CodeSample {
+	request := builder.request(api)
+	imports := []string{"fmt", "io", "net/http"}
+	body := "nil"
+	if request.HasBody {
+		imports = append(imports, "strings")
+		body = "body"
+	}
+
+	source := "package main\n\nimport (\n"
+	for _, name := range imports {
+		source += fmt.Sprintf("\t%q\n", name)
+	}
+	source += ")\n\nfunc main() {\n"
+	if request.HasBody {
+		content := marshalSample(request.Body, "\t")
+		if strings.Contains(content, "`") {
+			content = strconv.Quote(content)
+		} else {
+			content = "`" + content + "`"
+		}
+		source += fmt.Sprintf("\tbody := strings.NewReader(%s)\n", content)
+	}
+	source += fmt.Sprintf("\trequest, err := http.NewRequest(%q, %q, %s)\n", request.Method, request.Url, body)
+	source += "\tif err != nil {\n\t\tpanic(err)\n\t}\n"
+	for _, header := range request.Headers {
+		source += fmt.Sprintf("\trequest.Header.Set(%q, %q)\n", header[0], header[1])
+	}
+	if request.HasBody {
+		source += "\trequest.Header.Set(\"Content-Type\", \"application/json\")\n"
+	}
+	if request.Username != "" {
+		source += fmt.Sprintf("\trequest.SetBasicAuth(%q, %q)\n", request.Username, request.Password)
+	}
+	for _, cookie := range request.Cookies {
+		source += fmt.Sprintf("\trequest.AddCookie(&http.Cookie{Name: %q, Value: %q})\n", cookie[0], cookie[1])
+	}
+	source += "\n\tresponse, err := http.DefaultClient.Do(request)\n"
+	source += "\tif err != nil {\n\t\tpanic(err)\n\t}\n"
+	source += "\tdefer response.Body.Close()\n"
+	source += "\tcontent, err := io.ReadAll(response.Body)\n"
+	source += "\tif err != nil {\n\t\tpanic(err)\n\t}\n"
+	source += "\tfmt.Println(response.Status, string(content))\n}"
+	return CodeSample{Lang: "go", Label: "Go", Source: source}
+}
+
+// build the python requests script sending the request of an API
+func (builder *SampleBuilder) Python(api Api) CodeSample {
+	request := builder.request(api)
+	method := strings.ToLower(request.Method)
+	arguments := []string{jsonQuote(request.Url)}
+	if !pythonRequestsMethods[method] {
+		method = "request"
+		arguments = append([]string{jsonQuote(request.Method)}, arguments...)
+	}
+	if len(request.Headers) > 0 {
+		arguments = append(arguments, "headers="+formatPairs(request.Headers))
+	}
+	if len(request.Cookies) > 0 {
+		arguments = append(arguments, "cookies="+formatPairs(request.Cookies))
+	}
+	if request.Username != "" {
+		arguments = append(arguments, fmt.Sprintf("auth=(%s, %s)", jsonQuote(request.Username),
+			jsonQuote(request.Password)))
+	}
+	if request.HasBody {
+		arguments = append(arguments, "json="+pythonLiteral(request.Body, "    "))
+	}
+
+	source := fmt.Sprintf("import requests\n\nresponse = requests.%s(\n", method)
+	for _, argument := range arguments {
+		source += fmt.Sprintf("    %s,\n", argument)
+	}
+	source += ")\nprint(response.status_code, response.text)"
+	return CodeSample{Lang: "python", Label: "Python", Source: source}
+}
+
+// build the javascript fetch call sending the request of an API
+func (builder *SampleBuilder) JavaScript(api Api) CodeSample {
+	request := builder.request(api)
+	headers := make([]string, 0)
+	for _, header := range request.Headers {
+		headers = append(headers, fmt.Sprintf("%s: %s", jsonQuote(header[0]), jsonQuote(header[1])))
+	}
+	if request.HasBody {
+		headers = append(headers, fmt.Sprintf("%s: %s", jsonQuote("Content-Type"), jsonQuote("application/json")))
+	}
+	if request.Username != "" {
+		headers = append(headers, fmt.Sprintf("%s: \"Basic \" + btoa(%s)", jsonQuote("Authorization"),
+			jsonQuote(request.Username+":"+request.Password)))
+	}
+	if len(request.Cookies) > 0 {
+		headers = append(headers, fmt.Sprintf("%s: %s", jsonQuote("Cookie"), jsonQuote(formatCookies(request.Cookies))))
+	}
+
+	source := fmt.Sprintf("const response = await fetch(%s, {\n  method: %s,\n", jsonQuote(request.Url),
+		jsonQuote(request.Method))
+	if len(headers) > 0 {
+		source += "  headers: {\n"
+		for _, header := range headers {
+			source += fmt.Sprintf("    %s,\n", header)
+		}
+		source += "  },\n"
+	}
+	if request.HasBody {
+		source += fmt.Sprintf("  body: JSON.stringify(%s),\n", marshalSample(request.Body, "  "))
+	}
+	source += "});\nconsole.log(response.status, await response.text());"
+	return CodeSample{Lang: "javascript", Label: "JavaScript", Source: source}
+}
+
+// add the code samples of every API of a doc in the given languages, unless the spec has a sample in a language
+func (builder *SampleBuilder) AddSamples(doc *Document, languages []SampleLanguage) {
+	for index, api := range doc.Apis {
+		samples := api.CodeSamples
+		for _, language := range languages {
+			if sample := builder.Build(api, language); !hasCodeSample(api.CodeSamples, sample) {
+				samples = append(samples, sample)
+			}
+		}
+		doc.Apis[index].CodeSamples = samples
+	}
+}
+
+// whether samples have one in the language of a sample, matched by language or label
+func hasCodeSample(samples []CodeSample, sample CodeSample) bool {
+	for _, current := range samples {
+		for _, name := range []string{current.Lang, current.Label} {
+			if strings.EqualFold(name, sample.Lang) || strings.EqualFold(name, sample.Label) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// quote a string as a json string, which is a valid python and javascript string as well
+func jsonQuote(value string) string {
+	return marshalSample(value, "")
+}
+
+// format name and value pairs as a single line python dict
+func formatPairs(pairs [][2]string) string {
+	formatted := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		formatted = append(formatted, jsonQuote(pair[0])+": "+jsonQuote(pair[1]))
+	}
+	return "{" + strings.Join(formatted, ", ") + "}"
+}
+
+// format a json value as a python literal, nested lines are indented from indent
+func pythonLiteral(value interface{}, indent string) string {
+	switch value := value.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if value {
+			return "True"
+		}
+		return "False"
+	case string:
+		return jsonQuote(value)
+	case map[string]interface{}:
+		if len(value) == 0 {
+			return "{}"
+		}
+		items := make([]string, 0, len(value))
+		for _, key := range sortedObjectKeys(value) {
+			items = append(items, fmt.Sprintf("%s    %s: %s", indent, jsonQuote(key),
+				pythonLiteral(value[key], indent+"    ")))
+		}
+		return "{\n" + strings.Join(items, ",\n") + ",\n" + indent + "}"
+	case []interface{}:
+		if len(value) == 0 {
+			return "[]"
+		}
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, indent+"    "+pythonLiteral(item, indent+"    "))
+		}
+		return "[\n" + strings.Join(items, ",\n") + ",\n" + indent + "]"
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// test Go, Python and JavaScript in SampleBuilder
+func TestSampleBuilder_Languages(t *testing.T) {
+	analyzer := NewSwaggerAnalyzer(ENGLISH)
+	doc, err := analyzer.Extract(sampleSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder := NewSampleBuilder(doc.Model)
+
+	t.Log("Test code samples - request of every language")
+	{
+		for language, expected := range map[SampleLanguage][]string{
+			GO_SAMPLE: {"\t\"strings\"\n", "body := strings.NewReader(`{\n\t  \"name\": \"Rex\",",
+				"http.NewRequest(\"POST\", \"https://eu.pets.io/v1/pets\", body)",
+				"request.Header.Set(\"Authorization\", \"Bearer <TOKEN>\")"},
+			PYTHON_SAMPLE: {"response = requests.post(\n    \"https://eu.pets.io/v1/pets\",\n",
+				"    json={\n        \"name\": \"Rex\",\n        \"parent\": None,\n"},
+			JAVASCRIPT_SAMPLE: {"await fetch(\"https://eu.pets.io/v1/pets\", {\n  method: \"POST\",\n",
+				"    \"Content-Type\": \"application/json\",\n", "  body: JSON.stringify({\n    \"name\": \"Rex\","},
+		} {
+			source := builder.Build(doc.Apis[0], language).Source
+			for _, line := range expected {
+				if !strings.Contains(source, line) {
+					t.Errorf("expected %q in:\n%s", line, source)
+				}
+			}
+		}
+	}
+
+	t.Log("Test code samples - samples of the spec take precedence")
+	{
+		builder.AddSamples(doc, []SampleLanguage{CURL_SAMPLE, PYTHON_SAMPLE, JAVASCRIPT_SAMPLE})
+		for _, api := range doc.Apis {
+			labels := make([]string, 0)
+			for _, sample := range api.CodeSamples {
+				labels = append(labels, sample.Label)
+			}
+			expected := map[string]string{"createPet": "curl,Python,JavaScript",
+				"showPet": "Python,curl,JavaScript"}[api.OperationId]
+			if strings.Join(labels, ",") != expected {
+				t.Errorf("expected %s samples, got %v", expected, labels)
+			}
+		}
+	}
+
+	t.Log("Test code samples - rendered code blocks")
+	{
+		result, err := analyzer.Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"    #### Code samples\n    + curl\n\n    ```shell\n    curl -X POST",
+			"    + Python\n\n    ```Python\n    print('showPet')\n", "    ```javascript\n    const response"} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("expected %q in:\n%s", expected, result)
+			}
+		}
+	}
+}
@@ -7,6 +7,9 @@ type Property struct {
 	Type string
 	Example string
 	Required bool
+	Description string
+	Enum []string
+	Deprecation
 }
 
 func(p Property) String() string {
@@ -17,6 +20,7 @@ func(p Property) String() string {
 type Component struct {
 	Name string
 	Type string
+	Description string
 	Properties []Property
 	Code string
 }
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+type ConfluenceRenderer struct {
+	terms map[string]string // terms associated with language settings
+}
+
+// render an extracted doc in confluence storage format, ready to be uploaded as a page body
+func (renderer *ConfluenceRenderer) Render(doc *Document) (string, error) {
+	content := renderer.GetMacro("toc", map[string]string{"maxLevel": "3"}, "")
+	content += renderer.FormatOverview(doc.Model)
+	if len(doc.ComponentDiagrams) > 0 {
+		content += renderer.FormatComponentDiagrams(doc.ComponentDiagrams)
+	}
+	content += renderer.FormatComponents(doc)
+	content += renderer.FormatPaths(doc)
+	if len(doc.Deprecations) > 0 {
+		content += renderer.FormatDeprecations(doc.Deprecations)
+	}
+	return content, nil
+}
+
+// format the overview section, including info, servers and tags
+func (renderer *ConfluenceRenderer) FormatOverview(swaggerModel *Model) string {
+	overview := renderer.GetAnchor("overview")
+	overview += fmt.Sprintf("<h1>%s</h1>\n", renderer.term("overview"))
+	overview += fmt.Sprintf("<p><strong>%s</strong></p>\n", html.EscapeString(swaggerModel.Info.Description))
+
+	overview += fmt.Sprintf("<h2>%s</h2>\n", renderer.term("contact"))
+	overview += renderer.formatStringMap(swaggerModel.Info.Contact)
+	overview += fmt.Sprintf("<h2>%s</h2>\n", renderer.term("license"))
+	overview += renderer.formatStringMap(swaggerModel.Info.License)
+	overview += fmt.Sprintf("<h2>%s</h2>\n<p>%s</p>\n", renderer.term("version"),
+		html.EscapeString(swaggerModel.Info.Version))
+
+	overview += fmt.Sprintf("<h2>%s</h2>\n<ul>\n", renderer.term("servers"))
+	for index, server := range swaggerModel.Servers {
+		overview += fmt.Sprintf("<li>%s<ul><li>%s : <code>%s</code></li><li>%s : %s</li></ul></li>\n",
+			html.EscapeString(LocalizeServerName(renderer.terms, index)), renderer.term("url"),
+			html.EscapeString(server.Url), renderer.term("description"), html.EscapeString(server.Description))
+	}
+	overview += "</ul>\n"
+
+	overview += fmt.Sprintf("<h2>%s</h2>\n<ul>\n", renderer.term("tags"))
+	for _, tag := range swaggerModel.Tags {
+		overview += fmt.Sprintf("<li><strong><em>%s</em></strong> : %s</li>\n",
+			html.EscapeString(tag.Name), html.EscapeString(tag.Description))
+	}
+	overview += "</ul>\n"
+	return overview
+}
+
+// format the components section
+func (renderer *ConfluenceRenderer) FormatComponents(doc *Document) string {
+	content := renderer.GetAnchor("components")
+	content += fmt.Sprintf("<h1>%s</h1>\n", renderer.term("components"))
+	for _, component := range doc.Components {
+		content += renderer.FormatComponent(doc, &component)
+	}
+	return content
+}
+
+// format a single component, the json representation of which is folded in an expand macro
+func (renderer *ConfluenceRenderer) FormatComponent(doc *Document, component *Component) string {
+	content := renderer.GetAnchor(doc.ComponentAnchor(component.Name))
+	content += fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(component.Name))
+	content += formatHtmlParagraphs(component.Description)
+	content += fmt.Sprintf("<p>%s : <code>%s</code></p>\n", renderer.term("type"), html.EscapeString(component.Type))
+
+	rows := make([][]string, 0, len(component.Properties))
+	for _, property := range component.Properties {
+		rows = append(rows, []string{renderer.FormatDeprecated(html.EscapeString(property.Name), property.Deprecation),
+			fmt.Sprintf("<em>%s</em>", html.EscapeString(property.Type)),
+			html.EscapeString(LocalizeBool(renderer.terms, property.Required)), html.EscapeString(property.Description),
+			html.EscapeString(property.Example)})
+	}
+	content += renderer.GetTable(componentTableHeader, rows)
+
+	codeMacro := renderer.GetCodeMacro("json", component.Code)
+	content += renderer.GetMacro("expand", map[string]string{"title": renderer.terms["json_representation"]},
+		fmt.Sprintf("<ac:rich-text-body>%s</ac:rich-text-body>", codeMacro))
+
+	if usages := doc.ComponentUsages(component.Name); len(usages) > 0 {
+		links := make([]string, 0, len(usages))
+		for _, api := range usages {
+			links = append(links, renderer.GetAnchorLink(doc.ApiAnchor(api),
+				fmt.Sprintf("%s %s", strings.ToUpper(api.Method), api.Path)))
+		}
+		content += fmt.Sprintf("<p>%s: %s</p>\n", renderer.term("used_by"), strings.Join(links, ", "))
+	}
+	return content
+}
+
+// format the paths section
+func (renderer *ConfluenceRenderer) FormatPaths(doc *Document) string {
+	content := renderer.GetAnchor("paths")
+	content += fmt.Sprintf("<h1>%s</h1>\n", renderer.term("paths"))
+	for index, api := range doc.Apis {
+		content += renderer.FormatAPI(doc, index+1, api)
+	}
+	return content
+}
+
+// format an API
+func (renderer *ConfluenceRenderer) FormatAPI(doc *Document, apiIndex int, api Api) string {
+	content := renderer.GetAnchor(doc.ApiAnchor(api))
+	content += fmt.Sprintf("<h2>%d. %s</h2>\n", apiIndex,
+		renderer.FormatDeprecated(html.EscapeString(api.Title()), api.Deprecation))
+	content += formatHtmlParagraphs(api.Description)
+	content += fmt.Sprintf("<p>%s : <code>%s</code></p>\n", renderer.term("operation_id"),
+		html.EscapeString(api.OperationId))
+	content += renderer.GetCodeMacro("text", fmt.Sprintf("%s %s", strings.ToUpper(api.Method), api.Path))
+
+	if len(api.Parameters) > 0 {
+		rows := make([][]string, 0, len(api.Parameters))
+		for _, parameter := range api.Parameters {
+			rows = append(rows, []string{html.EscapeString(parameter.In),
+				renderer.FormatDeprecated(html.EscapeString(parameter.Name), parameter.Deprecation),
+				html.EscapeString(parameter.Description), html.EscapeString(parameter.Type)})
+		}
+		content += fmt.Sprintf("<h3>%s</h3>\n", renderer.term("parameters"))
+		content += renderer.GetTable(parameterTableHeader, rows)
+	}
+
+	if len(api.Responses) > 0 {
+		rows := make([][]string, 0, len(api.Responses))
+		for _, response := range api.Responses {
+			rows = append(rows, []string{html.EscapeString(response.StatusCode),
+				html.EscapeString(response.Description),
+				html.EscapeString(LocalizeSchema(renderer.terms, response.Schema))})
+		}
+		content += fmt.Sprintf("<h3>%s</h3>\n", renderer.term("responses"))
+		content += renderer.GetTable(responseTableHeader, rows)
+	}
+
+	if len(api.CodeSamples) > 0 {
+		content += fmt.Sprintf("<h3>%s</h3>\n", renderer.term("code_samples"))
+		for _, sample := range api.CodeSamples {
+			content += fmt.Sprintf("<p>%s</p>\n%s\n", html.EscapeString(sample.Label),
+				renderer.GetCodeMacro(sample.Lang, sample.Source))
+		}
+	}
+
+	if refs := doc.ApiComponentRefs(api); len(refs) > 0 {
+		links := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			links = append(links, renderer.GetAnchorLink(doc.ComponentAnchor(ref), ref))
+		}
+		content += fmt.Sprintf("<h3>%s</h3>\n<p>%s</p>\n", renderer.term("components"), strings.Join(links, ", "))
+	}
+
+	content += fmt.Sprintf("<h3>%s</h3>\n<ul>\n", renderer.term("tags"))
+	for _, tag := range api.Tags {
+		content += fmt.Sprintf("<li>%s</li>\n", html.EscapeString(tag))
+	}
+	content += "</ul>\n"
+
+	if api.Origin != "" {
+		content += fmt.Sprintf("<h3>%s</h3>\n<p>%s</p>\n", renderer.term("origin"), html.EscapeString(api.Origin))
+	}
+	return content
+}
+
+// format the components overview section, diagrams are code macros since confluence has no mermaid macro
+func (renderer *ConfluenceRenderer) FormatComponentDiagrams(diagrams []ComponentDiagram) string {
+	content := renderer.GetAnchor("components-overview")
+	content += fmt.Sprintf("<h1>%s</h1>\n", renderer.term("components_overview"))
+	for _, diagram := range diagrams {
+		if diagram.Tag != "" {
+			content += fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(diagram.Tag))
+		}
+		content += renderer.GetCodeMacro("mermaid", diagram.Source) + "\n"
+		if diagram.Omitted > 0 {
+			content += fmt.Sprintf("<p>%s</p>\n",
+				html.EscapeString(FormatOmittedComponents(renderer.terms, diagram.Omitted)))
+		}
+	}
+	return content
+}
+
+// format the deprecations section
+func (renderer *ConfluenceRenderer) FormatDeprecations(elements []DeprecatedElement) string {
+	content := renderer.GetAnchor("deprecations")
+	content += fmt.Sprintf("<h1>%s</h1>\n", renderer.term("deprecations"))
+	content += renderer.GetTable(deprecationTableHeader, deprecationRows(renderer.terms, elements, html.EscapeString))
+	return content
+}
+
+// strike an escaped name through when deprecated, followed by a status macro and the details of the deprecation
+func (renderer *ConfluenceRenderer) FormatDeprecated(name string, deprecation Deprecation) string {
+	if !deprecation.Deprecated {
+		return name
+	}
+	status := strings.TrimSuffix(renderer.GetMacro("status",
+		map[string]string{"colour": "Red", "title": renderer.terms["deprecated"]}, ""), "\n")
+	marked := fmt.Sprintf("<del>%s</del> %s", name, status)
+	if details := FormatDeprecationDetails(renderer.terms, deprecation.DeprecationDetails); details != "" {
+		marked += fmt.Sprintf(" (%s)", html.EscapeString(details))
+	}
+	return marked
+}
+
+// generate a structured macro with parameters and an optional body
+func (renderer *ConfluenceRenderer) GetMacro(name string, parameters map[string]string, body string) string {
+	macro := fmt.Sprintf("<ac:structured-macro ac:name=\"%s\">", name)
+	for _, key := range sortedKeys(parameters) {
+		macro += fmt.Sprintf("<ac:parameter ac:name=\"%s\">%s</ac:parameter>",
+			html.EscapeString(key), html.EscapeString(parameters[key]))
+	}
+	macro += body + "</ac:structured-macro>\n"
+	return macro
+}
+
+// generate a code macro, the content of which is kept verbatim
+func (renderer *ConfluenceRenderer) GetCodeMacro(language string, code string) string {
+	body := fmt.Sprintf("<ac:plain-text-body>%s</ac:plain-text-body>", renderer.cdata(code))
+	return renderer.GetMacro("code", map[string]string{"language": language}, body)
+}
+
+// generate an anchor macro
+func (renderer *ConfluenceRenderer) GetAnchor(name string) string {
+	return renderer.GetMacro("anchor", map[string]string{"": name}, "")
+}
+
+// generate a link to an anchor in the same page
+func (renderer *ConfluenceRenderer) GetAnchorLink(anchor string, text string) string {
+	return fmt.Sprintf("<ac:link ac:anchor=\"%s\"><ac:plain-text-link-body>%s</ac:plain-text-link-body></ac:link>",
+		html.EscapeString(anchor), renderer.cdata(text))
+}
+
+// generate a table with a localized header, the cells of which must have been escaped already
+func (renderer *ConfluenceRenderer) GetTable(header []string, rows [][]string) string {
+	table := "<table>\n<tbody>\n<tr>"
+	for _, label := range LocalizeHeader(renderer.terms, header) {
+		table += fmt.Sprintf("<th>%s</th>", html.EscapeString(label))
+	}
+	table += "</tr>\n"
+	for _, row := range rows {
+		table += "<tr>"
+		for _, cell := range row {
+			table += fmt.Sprintf("<td>%s</td>", cell)
+		}
+		table += "</tr>\n"
+	}
+	table += "</tbody>\n</table>\n"
+	return table
+}
+
+// wrap content in a CDATA section, splitting any terminator contained in the content
+func (renderer *ConfluenceRenderer) cdata(content string) string {
+	return "<![CDATA[" + strings.Replace(content, "]]>", "]]]]><![CDATA[>", -1) + "]]>"
+}
+
+// format a string map as a list, ordered by keys
+func (renderer *ConfluenceRenderer) formatStringMap(values map[string]string) string {
+	content := "<ul>\n"
+	for _, key := range sortedKeys(values) {
+		content += fmt.Sprintf("<li>%s : %s</li>\n", html.EscapeString(key), html.EscapeString(values[key]))
+	}
+	content += "</ul>\n"
+	return content
+}
+
+// escaped term of the current language
+func (renderer *ConfluenceRenderer) term(key string) string {
+	return html.EscapeString(renderer.terms[key])
+}
+
+// factory for ConfluenceRenderer
+func NewConfluenceRenderer(terms map[string]string) *ConfluenceRenderer {
+	return &ConfluenceRenderer{terms: terms}
+}
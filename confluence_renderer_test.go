@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// test the macros of ConfluenceRenderer
+func TestConfluenceRenderer_Macros(t *testing.T) {
+	renderer := NewConfluenceRenderer(map[string]string{})
+
+	t.Log("Test confluence renderer - GetAnchor")
+	{
+		expected := "<ac:structured-macro ac:name=\"anchor\"><ac:parameter ac:name=\"\">component-pet</ac:parameter>" +
+			"</ac:structured-macro>\n"
+		if anchor := renderer.GetAnchor("component-pet"); anchor != expected {
+			t.Errorf("expected %q, got %q", expected, anchor)
+		}
+	}
+
+	t.Log("Test confluence renderer - GetCodeMacro keeps the code verbatim")
+	{
+		expected := "<ac:structured-macro ac:name=\"code\"><ac:parameter ac:name=\"language\">json</ac:parameter>" +
+			"<ac:plain-text-body><![CDATA[{\"a\": \"<b> & ]]]]><![CDATA[>\"}]]></ac:plain-text-body></ac:structured-macro>\n"
+		if macro := renderer.GetCodeMacro("json", "{\"a\": \"<b> & ]]>\"}"); macro != expected {
+			t.Errorf("expected %q, got %q", expected, macro)
+		}
+	}
+
+	t.Log("Test confluence renderer - GetMacro escapes its parameters")
+	{
+		macro := renderer.GetMacro("expand", map[string]string{"title": "<JSON> & \"more\""}, "")
+		if !strings.Contains(macro, "<ac:parameter ac:name=\"title\">&lt;JSON&gt; &amp; &#34;more&#34;</ac:parameter>") {
+			t.Errorf("the parameter is not escaped: %q", macro)
+		}
+	}
+}
+
+// test Render in ConfluenceRenderer
+func TestConfluenceRenderer_Render(t *testing.T) {
+	t.Log("Test confluence renderer - Render")
+	{
+		analyzer := NewSwaggerAnalyzer(ENGLISH)
+		doc, err := analyzer.Extract(readTestSpec(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := NewConfluenceRenderer(analyzer.terms).Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{
+			"<p><strong>A sample API for &lt;pets&gt; &amp; owners</strong></p>\n",
+			"<ac:parameter ac:name=\"\">component-pet</ac:parameter></ac:structured-macro>\n<h2>Pet</h2>\n",
+			"<ac:structured-macro ac:name=\"expand\"><ac:parameter ac:name=\"title\">JSON representation</ac:parameter>" +
+				"<ac:rich-text-body><ac:structured-macro ac:name=\"code\">",
+			"<td><em>array&lt;string&gt;</em></td>",
+			"<ac:link ac:anchor=\"op-post-store-orders\"><ac:plain-text-link-body><![CDATA[POST /store/orders]]>",
+		} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("expected %q in confluence output", expected)
+			}
+		}
+		if strings.Contains(result, "<pets>") {
+			t.Errorf("unescaped text in the storage format")
+		}
+	}
+}
@@ -1,6 +1,12 @@
 package main
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
 
 type ContentSource int
 
@@ -24,26 +30,60 @@ type SwaggerContentGetter struct {
 	contentSource ContentSource
 }
 
+// get the content of a spec as json, yaml specs are converted
 func (scg *SwaggerContentGetter) GetContent() (string, error) {
+	var content string
+	var err error
 	switch scg.contentSource {
 	case LOCAL_SOURCE:
-		return scg.GetLocalContent()
+		content, err = scg.GetLocalContent()
 	case WEB_SOURCE:
-		return scg.GetWebContent()
+		content, err = scg.GetWebContent()
 	default:
 		return "", InvalidContentSource
 	}
+	if err != nil {
+		return "", err
+	}
+	return DecodeSpec(content)
 }
 
+// read content from a local file
 func (scg *SwaggerContentGetter) GetLocalContent() (string, error) {
-	return "", nil
+	content, err := ioutil.ReadFile(scg.contentPath)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
 }
 
+// download content from a web url
 func (scg *SwaggerContentGetter) GetWebContent() (string, error) {
-	return "", nil
+	resp, err := http.Get(scg.contentPath)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get %s: %s", scg.contentPath, resp.Status)
+	}
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
 }
 
 func NewSwaggerContentGetter(contentPath string, origin ContentSource) *SwaggerContentGetter {
 	getter := &SwaggerContentGetter{contentPath: contentPath, contentSource:origin}
 	return getter
-}
\ No newline at end of file
+}
+
+// source of an input given on the command line, http(s) urls are read from the web
+func DetectContentSource(input string) ContentSource {
+	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
+		return WEB_SOURCE
+	}
+	return LOCAL_SOURCE
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// server url of the samples of a doc declaring no server
+const DEFAULT_SAMPLE_SERVER = "http://localhost"
+
+// SampleBuilder struct, building sample requests from the servers, security schemes and schemas of a doc
+type SampleBuilder struct {
+	model *Model
+}
+
+// sample request of an API
+type sampleRequest struct {
+	Method   string
+	Url      string
+	Headers  [][2]string // name and value, in order
+	Cookies  [][2]string // name and value, in order
+	Username string      // basic authentication credentials, empty without basic authentication
+	Password string
+	HasBody  bool
+	Body     interface{} // json request body
+}
+
+// build the curl command of an API
+func (builder *SampleBuilder) Curl(api Api) CodeSample {
+	request := builder.request(api)
+	lines := []string{fmt.Sprintf("curl -X %s %s", request.Method, shellQuote(request.Url))}
+	if request.Username != "" {
+		lines = append(lines, "-u "+shellQuote(request.Username+":"+request.Password))
+	}
+	for _, header := range request.Headers {
+		lines = append(lines, "-H "+shellQuote(header[0]+": "+header[1]))
+	}
+	if len(request.Cookies) > 0 {
+		lines = append(lines, "--cookie "+shellQuote(formatCookies(request.Cookies)))
+	}
+	if request.HasBody {
+		lines = append(lines, "-H "+shellQuote("Content-Type: application/json"))
+		lines = append(lines, "-d "+shellQuote(marshalSample(request.Body, "")))
+	}
+	return CodeSample{Lang: "shell", Label: "curl", Source: strings.Join(lines, " \\\n  ")}
+}
+
+// collect the url, headers, cookies, credentials and body of the sample request of an API
+func (builder *SampleBuilder) request(api Api) sampleRequest {
+	request := sampleRequest{Method: strings.ToUpper(api.Method)}
+	apiPath := api.Path
+	query := make([]string, 0)
+	for _, parameter := range api.Parameters {
+		if parameter.In != "path" && !parameter.Required && parameter.Example == "" {
+			continue
+		}
+		value := builder.parameterValue(parameter)
+		switch parameter.In {
+		case "path":
+			apiPath = strings.Replace(apiPath, "{"+parameter.Name+"}", url.PathEscape(value), -1)
+		case "query":
+			query = append(query, url.QueryEscape(parameter.Name)+"="+url.QueryEscape(value))
+		case "header":
+			request.Headers = append(request.Headers, [2]string{parameter.Name, value})
+		case "cookie":
+			request.Cookies = append(request.Cookies, [2]string{parameter.Name, value})
+		}
+	}
+
+	// the first alternative of the security requirements is used
+	security := api.Security
+	if security == nil {
+		security = builder.model.Security
+	}
+	if len(security) > 0 {
+		for _, name := range sortedSchemeNames(security[0]) {
+			scheme := builder.model.Components.SecuritySchemes[name]
+			placeholder := "<" + strings.ToUpper(name) + ">"
+			switch {
+			case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+				request.Username, request.Password = "<USERNAME>", "<PASSWORD>"
+			case scheme.Type == "http" || scheme.Type == "oauth2" || scheme.Type == "openIdConnect":
+				request.Headers = append(request.Headers, [2]string{"Authorization", "Bearer <TOKEN>"})
+			case scheme.Type == "apiKey" && scheme.In == "query":
+				query = append(query, url.QueryEscape(scheme.Name)+"="+placeholder)
+			case scheme.Type == "apiKey" && scheme.In == "cookie":
+				request.Cookies = append(request.Cookies, [2]string{scheme.Name, placeholder})
+			case scheme.Type == "apiKey":
+				request.Headers = append(request.Headers, [2]string{scheme.Name, placeholder})
+			}
+		}
+	}
+
+	request.Url = builder.serverUrl() + apiPath
+	if len(query) > 0 {
+		request.Url += "?" + strings.Join(query, "&")
+	}
+	request.Body, request.HasBody = builder.requestBody(api)
+	return request
+}
+
+// url of the first server, its variables substituted by their default values
+func (builder *SampleBuilder) serverUrl() string {
+	if len(builder.model.Servers) == 0 {
+		return DEFAULT_SAMPLE_SERVER
+	}
+	server := builder.model.Servers[0]
+	serverUrl := server.Url
+	for name, variable := range server.Variables {
+		serverUrl = strings.Replace(serverUrl, "{"+name+"}", variable.Default, -1)
+	}
+	return strings.TrimSuffix(serverUrl, "/")
+}
+
+// sample value of a parameter, its example, its first allowed value or a value of its type
+func (builder *SampleBuilder) parameterValue(parameter Parameter) string {
+	if parameter.Example != "" {
+		return parameter.Example
+	}
+	if len(parameter.Enum) > 0 {
+		return parameter.Enum[0]
+	}
+	return fmt.Sprintf("%v", builder.synthesize(map[string]interface{}{"type": parameter.Type}, nil))
+}
+
+// json request body of an API, its example or one synthesized from its schema
+func (builder *SampleBuilder) requestBody(api Api) (interface{}, bool) {
+	requestBody := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(api.RequestBodyInJson), &requestBody); err != nil {
+		return nil, false
+	}
+	content, _ := requestBody["content"].(map[string]interface{})
+	media, ok := content["application/json"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if example, ok := media["example"]; ok {
+		return example, true
+	}
+	if examples, ok := media["examples"].(map[string]interface{}); ok {
+		for _, name := range sortedObjectKeys(examples) {
+			if example, ok := examples[name].(map[string]interface{})["value"]; ok {
+				return example, true
+			}
+		}
+	}
+	return builder.synthesize(media["schema"], nil), true
+}
+
+// synthesize a value of a schema from its examples, defaults and types, following component references,
+// refs are the references being followed, a recursive reference is synthesized as null
+func (builder *SampleBuilder) synthesize(schema interface{}, refs []string) interface{} {
+	fields, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if ref, ok := fields["$ref"].(string); ok {
+		for _, current := range refs {
+			if current == ref {
+				return nil
+			}
+		}
+		return builder.synthesize(builder.model.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")],
+			append(refs[:len(refs):len(refs)], ref))
+	}
+	for _, key := range []string{"example", "default"} {
+		if value, ok := fields[key]; ok {
+			return value
+		}
+	}
+	if values, ok := fields["enum"].([]interface{}); ok && len(values) > 0 {
+		return values[0]
+	}
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		if schemas, ok := fields[key].([]interface{}); ok && len(schemas) > 0 {
+			if key != "allOf" {
+				return builder.synthesize(schemas[0], refs)
+			}
+			merged := make(map[string]interface{})
+			for _, schema := range schemas {
+				if object, ok := builder.synthesize(schema, refs).(map[string]interface{}); ok {
+					for name, value := range object {
+						merged[name] = value
+					}
+				}
+			}
+			return merged
+		}
+	}
+
+	switch fields["type"] {
+	case "array":
+		if item := builder.synthesize(fields["items"], refs); item != nil {
+			return []interface{}{item}
+		}
+		return []interface{}{}
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return true
+	case "string":
+		return "string"
+	}
+	object := make(map[string]interface{})
+	properties, _ := fields["properties"].(map[string]interface{})
+	for name, property := range properties {
+		object[name] = builder.synthesize(property, refs)
+	}
+	return object
+}
+
+// names of the schemes of a security requirement, in sorted order
+func sortedSchemeNames(requirement map[string][]string) []string {
+	names := make([]string, 0, len(requirement))
+	for name := range requirement {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// indented json of a sample value, every line but the first is prefixed
+func marshalSample(value interface{}, prefix string) string {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent(prefix, "  ")
+	if err := encoder.Encode(value); err != nil {
+		return "null"
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// quote an argument for a posix shell
+func shellQuote(argument string) string {
+	return "'" + strings.Replace(argument, "'", `'\''`, -1) + "'"
+}
+
+// format cookies as the value of a cookie header
+func formatCookies(cookies [][2]string) string {
+	formatted := make([]string, 0, len(cookies))
+	for _, cookie := range cookies {
+		formatted = append(formatted, cookie[0]+"="+cookie[1])
+	}
+	return strings.Join(formatted, "; ")
+}
+
+// factory for SampleBuilder
+func NewSampleBuilder(model *Model) *SampleBuilder {
+	return &SampleBuilder{model: model}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// spec with server variables, security schemes, parameters of every location and a referenced request body
+const sampleSpec = `{
+	"openapi": "3.0.0",
+	"info": {"title": "Samples", "version": "1.0.0"},
+	"servers": [{"url": "https://{region}.pets.io/v1/", "variables": {"region": {"default": "eu"}}}],
+	"security": [{"bearerAuth": []}],
+	"paths": {
+		"/pets/{petId}": {
+			"get": {"operationId": "showPet", "tags": ["pets"],
+				"parameters": [
+					{"name": "petId", "in": "path", "description": "", "required": true,
+						"schema": {"type": "string"}, "example": "a b"},
+					{"name": "fields", "in": "query", "description": "", "schema": {"type": "string"}},
+					{"name": "limit", "in": "query", "description": "", "required": true, "schema": {"type": "integer"}},
+					{"name": "X-Trace", "in": "header", "description": "", "schema": {"type": "string", "example": "it's"}},
+					{"name": "session", "in": "cookie", "description": "", "required": true,
+						"schema": {"type": "string", "enum": ["s1"]}}],
+				"security": [{"apiKey": []}],
+				"x-codeSamples": [{"lang": "Python", "source": "print('showPet')"}],
+				"responses": {"200": {"description": "ok"}}}
+		},
+		"/pets": {
+			"post": {"operationId": "createPet", "tags": ["pets"],
+				"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+				"responses": {"201": {"description": "created"}}}
+		}
+	},
+	"components": {
+		"schemas": {
+			"Pet": {"type": "object", "required": [], "properties": {
+				"name": {"type": "string", "example": "Rex"}, "tags": {"type": "array", "items": {"type": "string"}},
+				"parent": {"type": "object", "$ref": "#/components/schemas/Pet"}}}
+		},
+		"securitySchemes": {
+			"bearerAuth": {"type": "http", "scheme": "bearer"},
+			"apiKey": {"type": "apiKey", "in": "header", "name": "X-API-Key"}
+		}
+	}
+}`
+
+// test Curl in SampleBuilder
+func TestSampleBuilder_Curl(t *testing.T) {
+	analyzer := NewSwaggerAnalyzer(ENGLISH)
+	doc, err := analyzer.Extract(sampleSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder := NewSampleBuilder(doc.Model)
+	samples := make(map[string]string)
+	for _, api := range doc.Apis {
+		samples[api.OperationId] = builder.Curl(api).Source
+	}
+
+	t.Log("Test curl - parameters and security of the operation")
+	{
+		expected := "curl -X GET 'https://eu.pets.io/v1/pets/a%20b?limit=0' \\\n" +
+			"  -H 'X-Trace: it'\\''s' \\\n" +
+			"  -H 'X-API-Key: <APIKEY>' \\\n" +
+			"  --cookie 'session=s1'"
+		if samples["showPet"] != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, samples["showPet"])
+		}
+	}
+
+	t.Log("Test curl - security of the doc and synthesized body")
+	{
+		for _, expected := range []string{"curl -X POST 'https://eu.pets.io/v1/pets'",
+			"-H 'Authorization: Bearer <TOKEN>'", "-H 'Content-Type: application/json'",
+			"\"name\": \"Rex\",", "\"tags\": [\n    \"string\"\n  ]"} {
+			if !strings.Contains(samples["createPet"], expected) {
+				t.Errorf("expected %q in:\n%s", expected, samples["createPet"])
+			}
+		}
+	}
+}
+
+// test the curl examples of Transformer, an alias of the curl code samples
+func TestTransformer_CurlExamples(t *testing.T) {
+	t.Log("Test transformer - curl examples with and without code samples")
+	{
+		for _, codeSamples := range [][]SampleLanguage{nil, {CURL_SAMPLE, PYTHON_SAMPLE}} {
+			transformer := NewTransformer("testdata/petstore.json", t.TempDir(), LOCAL_SOURCE, ENGLISH, MARKDOWN_FORMAT)
+			transformer.CurlExamples = true
+			transformer.CodeSamples = codeSamples
+			if err := transformer.Run(); err != nil {
+				t.Fatal(err)
+			}
+			if strings.Count(transformer.OutputContent, "```shell\n    curl -X GET") != 2 {
+				t.Errorf("expected a single curl command per GET operation in:\n%s", transformer.OutputContent)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// prefix of the vendor extensions detailing a deprecation, e.g. x-deprecated-sunset or x-deprecated-replacement
+const DEPRECATED_EXTENSION_PREFIX = "x-deprecated-"
+
+var deprecationTableHeader = []string{ELEMENT, LOCATION, DETAIL}
+
+// Deprecation struct, embedded into the operations, parameters and properties of a spec
+type Deprecation struct {
+	Deprecated bool
+	// x-deprecated-* extensions by suffix, e.g. sunset for x-deprecated-sunset
+	DeprecationDetails map[string]string
+}
+
+// DeprecatedElement struct, an element listed in the deprecations section
+type DeprecatedElement struct {
+	Element  ChangeElement
+	Location string // e.g. GET /pets, GET /pets query limit, Pet.name
+	Deprecation
+}
+
+// extract the deprecation of an object, any x-deprecated-* extension deprecates it as well
+func extractDeprecation(object interface{}) Deprecation {
+	deprecation := Deprecation{}
+	fields, ok := object.(map[string]interface{})
+	if !ok {
+		return deprecation
+	}
+	deprecation.Deprecated, _ = fields["deprecated"].(bool)
+	for key, value := range fields {
+		if strings.HasPrefix(key, DEPRECATED_EXTENSION_PREFIX) {
+			if deprecation.DeprecationDetails == nil {
+				deprecation.DeprecationDetails = make(map[string]string)
+			}
+			deprecation.DeprecationDetails[strings.TrimPrefix(key, DEPRECATED_EXTENSION_PREFIX)] = fmt.Sprintf("%v", value)
+			deprecation.Deprecated = true
+		}
+	}
+	return deprecation
+}
+
+// every deprecated operation, parameter and property, in the order of the doc
+func (doc *Document) FindDeprecations() []DeprecatedElement {
+	elements := make([]DeprecatedElement, 0)
+	for _, api := range doc.Apis {
+		if api.Deprecated {
+			elements = append(elements, DeprecatedElement{Element: OPERATION_ELEMENT, Location: apiKey(api),
+				Deprecation: api.Deprecation})
+		}
+		for _, parameter := range api.Parameters {
+			if parameter.Deprecated {
+				elements = append(elements, DeprecatedElement{Element: PARAMETER_ELEMENT,
+					Location: apiKey(api) + " " + parameterKey(parameter), Deprecation: parameter.Deprecation})
+			}
+		}
+	}
+	for _, component := range doc.Components {
+		for _, property := range component.Properties {
+			if property.Deprecated {
+				elements = append(elements, DeprecatedElement{Element: PROPERTY_ELEMENT,
+					Location: component.Name + "." + property.Name, Deprecation: property.Deprecation})
+			}
+		}
+	}
+	return elements
+}
+
+// format the details of a deprecation ordered by key, e.g. Sunset: 2025-06-01, keys with a term are localized
+func FormatDeprecationDetails(terms map[string]string, details map[string]string) string {
+	formatted := make([]string, 0, len(details))
+	for _, key := range sortedKeys(details) {
+		label := key
+		if term, ok := terms[key]; ok {
+			label = term
+		}
+		formatted = append(formatted, fmt.Sprintf("%s: %s", label, details[key]))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// mark a deprecated name in markdown, struck through with a badge and the details of the deprecation
+func FormatMarkdownDeprecated(terms map[string]string, name string, deprecation Deprecation) string {
+	if !deprecation.Deprecated {
+		return name
+	}
+	marked := fmt.Sprintf("~~%s~~ `%s`", name, terms["deprecated"])
+	if details := FormatDeprecationDetails(terms, deprecation.DeprecationDetails); details != "" {
+		marked += fmt.Sprintf(" (%s)", markdownEscaper.Replace(details))
+	}
+	return marked
+}
+
+// rows of a deprecations table, element, location and detail, the cells of which are escaped by escape
+func deprecationRows(terms map[string]string, elements []DeprecatedElement, escape func(string) string) [][]string {
+	rows := make([][]string, 0, len(elements))
+	for _, element := range elements {
+		rows = append(rows, []string{escape(terms[string(element.Element)]), escape(element.Location),
+			escape(FormatDeprecationDetails(terms, element.DeprecationDetails))})
+	}
+	return rows
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// spec with a deprecated operation, parameter and property
+const deprecationSpec = `{
+	"openapi": "3.0.0",
+	"info": {"title": "Deprecations", "version": "1.0.0"},
+	"paths": {
+		"/pets": {
+			"get": {"operationId": "listPets", "tags": ["pets"],
+				"parameters": [{"name": "page", "in": "query", "description": "Page", "schema": {"type": "integer"},
+					"deprecated": true}],
+				"responses": {"200": {"description": "ok"}}},
+			"post": {"operationId": "createPet", "tags": ["pets"], "deprecated": true,
+				"x-deprecated-sunset": "2025-06-01", "x-deprecated-replacement": "PUT /pets",
+				"responses": {"201": {"description": "created"}}}
+		}
+	},
+	"components": {"schemas": {
+		"Pet": {"type": "object", "required": [], "properties": {
+			"name": {"type": "string"},
+			"nickname": {"type": "string", "x-deprecated-sunset": "2025-01-01"}}}
+	}}
+}`
+
+// test extracting and rendering deprecations
+func TestDeprecations(t *testing.T) {
+	analyzer := NewSwaggerAnalyzer(ENGLISH)
+	doc, err := analyzer.Extract(deprecationSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Test deprecations - FindDeprecations")
+	{
+		locations := make([]string, 0)
+		for _, element := range doc.FindDeprecations() {
+			locations = append(locations, string(element.Element)+" "+element.Location)
+		}
+		expected := "parameter GET /pets query page,operation POST /pets,property Pet.nickname"
+		if strings.Join(locations, ",") != expected {
+			t.Errorf("expected %s, got %v", expected, locations)
+		}
+	}
+
+	t.Log("Test deprecations - markdown markers and section")
+	{
+		result, err := analyzer.Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(result, "## Deprecations") {
+			t.Errorf("the deprecations section is optional")
+		}
+		doc.Deprecations = doc.FindDeprecations()
+		result, err = analyzer.Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{
+			"2. ~~***createPet***~~ `Deprecated` (Replacement: PUT /pets, Sunset: 2025-06-01)",
+			"|query|~~page~~ `Deprecated`|Page|integer|",
+			"|~~nickname~~ `Deprecated` (Sunset: 2025-01-01)|",
+			"## Deprecations\n\n|Element|Location|Detail|\n|---|---|---|\n|Parameter|GET /pets query page||\n",
+		} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("expected %q in:\n%s", expected, result)
+			}
+		}
+	}
+
+	t.Log("Test deprecations - html and chinese markers")
+	{
+		renderer, err := NewRenderer(HTML_FORMAT, NewSwaggerAnalyzer(CHINESE))
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := renderer.Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"<del>page</del> <span class=\"deprecated\">已弃用</span>",
+			"<span class=\"deprecation-details\">停用日期: 2025-01-01</span>", "<section id=\"deprecations\">"} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("expected %q in html", expected)
+			}
+		}
+	}
+}
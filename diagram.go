@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// number of classes above which a doc gets a diagram per tag instead of a single diagram
+const DEFAULT_DIAGRAM_LIMIT = 30
+
+var diagramIdInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// ComponentDiagram struct, a mermaid class diagram of the components of a doc
+type ComponentDiagram struct {
+	Tag     string // tag whose operations use the components, empty for a diagram of every component
+	Source  string // mermaid classDiagram
+	Omitted int    // components used by the tag but left out by the size limit
+}
+
+// relation of a class to a component, drawn when the component is in the diagram
+type diagramRelation struct {
+	Line   string
+	Target string
+}
+
+// ComponentDiagramBuilder struct, building class diagrams of the schemas of a doc and the references between them
+type ComponentDiagramBuilder struct {
+	Limit int // maximum number of classes of a diagram
+}
+
+// build a diagram of every component, or a diagram per tag when the components outnumber the limit
+func (builder *ComponentDiagramBuilder) Build(doc *Document) []ComponentDiagram {
+	schemas := doc.Model.Components.Schemas
+	if len(schemas) == 0 {
+		return nil
+	}
+	if len(schemas) <= builder.Limit {
+		return []ComponentDiagram{{Source: builder.classDiagram(schemas, sortedObjectKeys(schemas))}}
+	}
+
+	diagrams := make([]ComponentDiagram, 0)
+	tagNames, groups := doc.ApisByTag()
+	for _, tagName := range tagNames {
+		names := builder.usedComponents(doc, groups[tagName])
+		if len(names) == 0 {
+			continue
+		}
+		diagram := ComponentDiagram{Tag: tagName}
+		if len(names) > builder.Limit {
+			diagram.Omitted = len(names) - builder.Limit
+			names = names[:builder.Limit]
+		}
+		diagram.Source = builder.classDiagram(schemas, names)
+		diagrams = append(diagrams, diagram)
+	}
+	return diagrams
+}
+
+// components referenced by APIs, the directly referenced ones first, then the ones they reference
+func (builder *ComponentDiagramBuilder) usedComponents(doc *Document, apis []Api) []string {
+	pending := make([]string, 0)
+	for _, api := range apis {
+		pending = append(pending, doc.ApiComponentRefs(api)...)
+	}
+	found := make(map[string]bool)
+	names := make([]string, 0)
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		schema, ok := doc.Model.Components.Schemas[name]
+		if found[name] || !ok {
+			continue
+		}
+		found[name] = true
+		names = append(names, name)
+		pending = append(pending, findComponentRefs(marshalFiltered(schema))...)
+	}
+	return names
+}
+
+// mermaid class diagram of schemas, only the relations between the given classes are drawn
+func (builder *ComponentDiagramBuilder) classDiagram(schemas map[string]interface{}, names []string) string {
+	included := make(map[string]bool)
+	for _, name := range names {
+		included[name] = true
+	}
+	lines := []string{"classDiagram"}
+	relations := make([]diagramRelation, 0)
+	for _, name := range names {
+		id := diagramId(name)
+		if id != name {
+			lines = append(lines, fmt.Sprintf("  class %s[\"%s\"]", id, name))
+		}
+		members := make([]string, 0)
+		builder.describe(schemas[name], name, &members, &relations)
+		if len(members) == 0 {
+			lines = append(lines, "  class "+id)
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  class %s {", id))
+		for _, member := range members {
+			lines = append(lines, "    "+member)
+		}
+		lines = append(lines, "  }")
+	}
+	for _, relation := range relations {
+		if included[relation.Target] {
+			lines = append(lines, "  "+relation.Line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// collect the members of a class and its relations to other components
+func (builder *ComponentDiagramBuilder) describe(schema interface{}, name string, members *[]string,
+	relations *[]diagramRelation) {
+	fields, _ := schema.(map[string]interface{})
+	id := diagramId(name)
+
+	// allOf references are inherited from, oneOf and anyOf references are possible shapes
+	if schemas, ok := fields["allOf"].([]interface{}); ok {
+		for _, item := range schemas {
+			if ref := schemaRef(item); ref != "" {
+				*relations = append(*relations,
+					diagramRelation{fmt.Sprintf("%s <|-- %s", diagramId(ref), id), ref})
+			} else {
+				builder.describe(item, name, members, relations)
+			}
+		}
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		schemas, _ := fields[key].([]interface{})
+		for _, item := range schemas {
+			if ref := schemaRef(item); ref != "" {
+				*relations = append(*relations,
+					diagramRelation{fmt.Sprintf("%s ..> %s : %s", id, diagramId(ref), key), ref})
+			}
+		}
+	}
+	if fields["type"] == "array" {
+		if ref, many := relationTarget(fields); ref != "" && many {
+			*relations = append(*relations,
+				diagramRelation{fmt.Sprintf("%s --> \"*\" %s", id, diagramId(ref)), ref})
+		}
+	}
+
+	properties, _ := fields["properties"].(map[string]interface{})
+	for _, propertyName := range sortedObjectKeys(properties) {
+		property := properties[propertyName]
+		*members = append(*members, fmt.Sprintf("+%s %s", diagramType(property), propertyName))
+		if ref, many := relationTarget(property); ref != "" {
+			multiplicity := "1"
+			if many {
+				multiplicity = "*"
+			}
+			*relations = append(*relations, diagramRelation{fmt.Sprintf("%s --> \"%s\" %s : %s", id, multiplicity,
+				diagramId(ref), propertyName), ref})
+		}
+	}
+}
+
+// component referenced by a schema, directly or through its items or additional properties, many when not directly
+func relationTarget(schema interface{}) (string, bool) {
+	if ref := schemaRef(schema); ref != "" {
+		return ref, false
+	}
+	fields, _ := schema.(map[string]interface{})
+	for _, key := range []string{"items", "additionalProperties"} {
+		if ref := schemaRef(fields[key]); ref != "" {
+			return ref, true
+		}
+	}
+	return "", false
+}
+
+// name of the component a schema references, empty when it references none
+func schemaRef(schema interface{}) string {
+	fields, _ := schema.(map[string]interface{})
+	ref, _ := fields["$ref"].(string)
+	if match := componentRefPattern.FindStringSubmatch(ref); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// type of a property in a diagram, referenced components by name and arrays suffixed with []
+func diagramType(schema interface{}) string {
+	if ref := schemaRef(schema); ref != "" {
+		return ref
+	}
+	fields, _ := schema.(map[string]interface{})
+	schemaType, _ := fields["type"].(string)
+	switch {
+	case schemaType == "array":
+		return diagramType(fields["items"]) + "[]"
+	case schemaType == "":
+		return "object"
+	default:
+		return schemaType
+	}
+}
+
+// identifier of a component in a diagram, characters mermaid does not allow are replaced with _
+func diagramId(name string) string {
+	return diagramIdInvalidChars.ReplaceAllString(name, "_")
+}
+
+// format the number of components left out of a diagram, e.g. Components not shown : 3
+func FormatOmittedComponents(terms map[string]string, omitted int) string {
+	return fmt.Sprintf("%s : %d", terms["omitted_components"], omitted)
+}
+
+// factory for ComponentDiagramBuilder, a limit that is not positive falls back to DEFAULT_DIAGRAM_LIMIT
+func NewComponentDiagramBuilder(limit int) *ComponentDiagramBuilder {
+	if limit <= 0 {
+		limit = DEFAULT_DIAGRAM_LIMIT
+	}
+	return &ComponentDiagramBuilder{Limit: limit}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// spec whose components reference each other through properties, arrays, maps and composition,
+// written as real specs are, with $ref only schemas and without required lists
+const diagramSpec = `{
+	"openapi": "3.0.0",
+	"info": {"title": "Diagrams", "version": "1.0.0"},
+	"tags": [{"name": "pets"}, {"name": "store"}],
+	"paths": {
+		"/pets": {
+			"get": {"operationId": "listPets", "tags": ["pets"], "responses": {"200": {"description": "ok",
+				"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pets"}}}}}}
+		},
+		"/orders": {
+			"get": {"operationId": "listOrders", "tags": ["store"], "responses": {"200": {"description": "ok",
+				"content": {"application/json": {"schema": {"$ref": "#/components/schemas/store.Order"}}}}}}
+		}
+	},
+	"components": {"schemas": {
+		"Pets": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+		"Animal": {"type": "object", "properties": {"name": {"type": "string"}}},
+		"Pet": {"type": "object", "properties": {
+			"owner": {"$ref": "#/components/schemas/Owner"},
+			"toys": {"type": "array", "items": {"$ref": "#/components/schemas/Toy"}}},
+			"allOf": [{"$ref": "#/components/schemas/Animal"}, {"properties": {"age": {"type": "integer"}}}]},
+		"Owner": {"type": "object", "properties": {
+			"pets": {"type": "object", "additionalProperties": {"$ref": "#/components/schemas/Pet"}}}},
+		"Toy": {"oneOf": [{"$ref": "#/components/schemas/Animal"}]},
+		"store.Order": {"type": "object", "required": ["quantity"], "properties": {"quantity": {"type": "integer"}}}
+	}}
+}`
+
+// test Build in ComponentDiagramBuilder
+func TestComponentDiagramBuilder_Build(t *testing.T) {
+	analyzer := NewSwaggerAnalyzer(ENGLISH)
+	doc, err := analyzer.Extract(diagramSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Test diagram - $ref only schemas are extracted")
+	{
+		if doc.Apis[1].Responses[0].Schema != "Pets" {
+			t.Errorf("expected the referenced component as response schema, got %s", doc.Apis[1].Responses[0].Schema)
+		}
+		for _, component := range doc.Components {
+			if component.Name == "Pet" && (component.Properties[0].Type != "Owner" ||
+				component.Properties[1].Type != "array<Toy>") {
+				t.Errorf("expected referenced components as property types, got %v", component.Properties)
+			}
+			if component.Name == "Toy" && component.Type != "object" {
+				t.Errorf("expected an untyped component to be an object, got %s", component.Type)
+			}
+		}
+	}
+
+	t.Log("Test diagram - a single diagram of every component")
+	{
+		diagrams := NewComponentDiagramBuilder(0).Build(doc)
+		if len(diagrams) != 1 || diagrams[0].Tag != "" {
+			t.Fatalf("expected a single diagram, got %+v", diagrams)
+		}
+		for _, expected := range []string{"classDiagram\n  class Animal {\n    +string name\n  }\n",
+			"  class Pet {\n    +integer age\n    +Owner owner\n    +Toy[] toys\n  }\n", "  class Toy\n",
+			"  class store_Order[\"store.Order\"]\n  class store_Order {\n", "  Pets --> \"*\" Pet\n",
+			"  Animal <|-- Pet\n", "  Pet --> \"1\" Owner : owner\n", "  Pet --> \"*\" Toy : toys\n",
+			"  Owner --> \"*\" Pet : pets\n", "  Toy ..> Animal : oneOf"} {
+			if !strings.Contains(diagrams[0].Source, expected) {
+				t.Errorf("expected %q in:\n%s", expected, diagrams[0].Source)
+			}
+		}
+	}
+
+	t.Log("Test diagram - a diagram per tag above the limit")
+	{
+		diagrams := NewComponentDiagramBuilder(3).Build(doc)
+		if len(diagrams) != 2 || diagrams[0].Tag != "pets" || diagrams[1].Tag != "store" {
+			t.Fatalf("expected a diagram per tag, got %+v", diagrams)
+		}
+		if diagrams[0].Omitted != 2 || strings.Contains(diagrams[0].Source, "class Toy") ||
+			!strings.Contains(diagrams[0].Source, "  Animal <|-- Pet") {
+			t.Errorf("expected Pets, Pet and Animal, got %d omitted in:\n%s", diagrams[0].Omitted, diagrams[0].Source)
+		}
+	}
+
+	t.Log("Test diagram - components overview section")
+	{
+		doc.ComponentDiagrams = NewComponentDiagramBuilder(3).Build(doc)
+		result, err := analyzer.Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := "## Components overview\n\n### pets\n\n```mermaid\nclassDiagram\n"
+		if !strings.Contains(result, expected) || !strings.Contains(result, "Components not shown : 2\n\n") ||
+			strings.Index(result, expected) > strings.Index(result, "## Components\n") {
+			t.Errorf("expected the overview before the components in:\n%s", result)
+		}
+	}
+}
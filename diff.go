@@ -0,0 +1,326 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type ChangeKind string
+type ChangeElement string
+
+const (
+	ADDED_CHANGE   ChangeKind = "added"
+	REMOVED_CHANGE ChangeKind = "removed"
+	CHANGED_CHANGE ChangeKind = "changed"
+
+	OPERATION_ELEMENT    ChangeElement = "operation"
+	PARAMETER_ELEMENT    ChangeElement = "parameter"
+	REQUEST_BODY_ELEMENT ChangeElement = "request_body"
+	RESPONSE_ELEMENT     ChangeElement = "response"
+	COMPONENT_ELEMENT    ChangeElement = "component"
+	PROPERTY_ELEMENT     ChangeElement = "property"
+)
+
+// Change struct, a difference between two versions of a spec
+type Change struct {
+	Kind     ChangeKind    `json:"kind"`
+	Element  ChangeElement `json:"element"`
+	Location string        `json:"location"`        // e.g. GET /pets, GET /pets query limit, Pet.name
+	Field    string        `json:"field,omitempty"` // term key of the changed field, e.g. type, required or enum
+	From     string        `json:"from,omitempty"`
+	To       string        `json:"to,omitempty"`
+	Breaking bool          `json:"breaking"` // whether clients of the base version may break
+}
+
+// SpecDiff struct, the changes between a base and a revision of a spec
+type SpecDiff struct {
+	Base     *Document
+	Revision *Document
+	Changes  []Change
+}
+
+// changes which may break the clients of the base version
+func (diff *SpecDiff) Breaking() []Change {
+	return diff.filter(true)
+}
+
+// changes which are compatible with the clients of the base version
+func (diff *SpecDiff) NonBreaking() []Change {
+	return diff.filter(false)
+}
+
+func (diff *SpecDiff) filter(breaking bool) []Change {
+	changes := make([]Change, 0)
+	for _, change := range diff.Changes {
+		if change.Breaking == breaking {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func (diff *SpecDiff) add(change Change) {
+	diff.Changes = append(diff.Changes, change)
+}
+
+// compare two extracted docs, operations are matched by method and path, components by name
+func DiffDocuments(base *Document, revision *Document) *SpecDiff {
+	diff := &SpecDiff{Base: base, Revision: revision, Changes: make([]Change, 0)}
+
+	revisionApis := make(map[string]Api)
+	for _, api := range revision.Apis {
+		revisionApis[apiKey(api)] = api
+	}
+	baseApis := make(map[string]bool)
+	for _, api := range base.Apis {
+		baseApis[apiKey(api)] = true
+		if revisionApi, ok := revisionApis[apiKey(api)]; ok {
+			diff.diffApi(api, revisionApi)
+		} else {
+			diff.add(Change{Kind: REMOVED_CHANGE, Element: OPERATION_ELEMENT, Location: apiKey(api), Breaking: true})
+		}
+	}
+	for _, api := range revision.Apis {
+		if !baseApis[apiKey(api)] {
+			diff.add(Change{Kind: ADDED_CHANGE, Element: OPERATION_ELEMENT, Location: apiKey(api)})
+		}
+	}
+
+	revisionComponents := make(map[string]Component)
+	for _, component := range revision.Components {
+		revisionComponents[component.Name] = component
+	}
+	baseComponents := make(map[string]bool)
+	for _, component := range base.Components {
+		baseComponents[component.Name] = true
+		if revisionComponent, ok := revisionComponents[component.Name]; ok {
+			diff.diffComponent(component, revisionComponent)
+		} else {
+			diff.add(Change{Kind: REMOVED_CHANGE, Element: COMPONENT_ELEMENT, Location: component.Name, Breaking: true})
+		}
+	}
+	for _, component := range revision.Components {
+		if !baseComponents[component.Name] {
+			diff.add(Change{Kind: ADDED_CHANGE, Element: COMPONENT_ELEMENT, Location: component.Name})
+		}
+	}
+	return diff
+}
+
+// compare the parameters, request body and responses of an operation
+func (diff *SpecDiff) diffApi(base Api, revision Api) {
+	revisionParameters := make(map[string]Parameter)
+	for _, parameter := range revision.Parameters {
+		revisionParameters[parameterKey(parameter)] = parameter
+	}
+	baseParameters := make(map[string]bool)
+	for _, parameter := range base.Parameters {
+		location := apiKey(base) + " " + parameterKey(parameter)
+		baseParameters[parameterKey(parameter)] = true
+		revisionParameter, ok := revisionParameters[parameterKey(parameter)]
+		if !ok {
+			diff.add(Change{Kind: REMOVED_CHANGE, Element: PARAMETER_ELEMENT, Location: location, Breaking: true})
+			continue
+		}
+		diff.diffField(PARAMETER_ELEMENT, location, "type", parameter.Type, revisionParameter.Type)
+		diff.diffRequired(PARAMETER_ELEMENT, location, parameter.Required, revisionParameter.Required)
+		diff.diffEnum(PARAMETER_ELEMENT, location, parameter.Enum, revisionParameter.Enum)
+	}
+	for _, parameter := range revision.Parameters {
+		if !baseParameters[parameterKey(parameter)] {
+			diff.diffAdded(PARAMETER_ELEMENT, apiKey(revision)+" "+parameterKey(parameter), parameter.Required)
+		}
+	}
+
+	baseSchema, baseRequired, baseOk := requestBodySchema(base)
+	revisionSchema, revisionRequired, revisionOk := requestBodySchema(revision)
+	switch {
+	case baseOk && !revisionOk:
+		diff.add(Change{Kind: REMOVED_CHANGE, Element: REQUEST_BODY_ELEMENT, Location: apiKey(base), Breaking: true})
+	case !baseOk && revisionOk:
+		diff.diffAdded(REQUEST_BODY_ELEMENT, apiKey(revision), revisionRequired)
+	case baseOk && revisionOk:
+		diff.diffField(REQUEST_BODY_ELEMENT, apiKey(base), "schema", baseSchema, revisionSchema)
+		diff.diffRequired(REQUEST_BODY_ELEMENT, apiKey(base), baseRequired, revisionRequired)
+	}
+
+	revisionResponses := make(map[string]Response)
+	for _, response := range revision.Responses {
+		revisionResponses[response.StatusCode] = response
+	}
+	baseResponses := make(map[string]bool)
+	for _, response := range base.Responses {
+		location := apiKey(base) + " " + response.StatusCode
+		baseResponses[response.StatusCode] = true
+		revisionResponse, ok := revisionResponses[response.StatusCode]
+		if !ok {
+			diff.add(Change{Kind: REMOVED_CHANGE, Element: RESPONSE_ELEMENT, Location: location, Breaking: true})
+			continue
+		}
+		diff.diffField(RESPONSE_ELEMENT, location, "schema", response.Schema, revisionResponse.Schema)
+		diff.diffInlineSchema(location, responseSchema(base, response.StatusCode),
+			responseSchema(revision, response.StatusCode))
+	}
+	for _, response := range revision.Responses {
+		if !baseResponses[response.StatusCode] {
+			diff.add(Change{Kind: ADDED_CHANGE, Element: RESPONSE_ELEMENT,
+				Location: apiKey(revision) + " " + response.StatusCode})
+		}
+	}
+}
+
+// compare the type and properties of a component
+func (diff *SpecDiff) diffComponent(base Component, revision Component) {
+	diff.diffField(COMPONENT_ELEMENT, base.Name, "type", base.Type, revision.Type)
+	diff.diffProperties(base.Name, base.Properties, revision.Properties)
+}
+
+// compare the properties of inline schemas of the same type, nested objects and array items included, the
+// components they reference are compared on their own
+func (diff *SpecDiff) diffInlineSchema(location string, base interface{}, revision interface{}) {
+	baseSchema, _ := base.(map[string]interface{})
+	revisionSchema, _ := revision.(map[string]interface{})
+	_, baseRef := baseSchema["$ref"]
+	_, revisionRef := revisionSchema["$ref"]
+	if baseSchema == nil || revisionSchema == nil || baseRef || revisionRef ||
+		extractSchemaType(baseSchema) != extractSchemaType(revisionSchema) {
+		return
+	}
+	if extractSchemaType(baseSchema) == "array" {
+		diff.diffInlineSchema(location+"[]", baseSchema["items"], revisionSchema["items"])
+		return
+	}
+
+	diff.diffProperties(location, extractProperties(baseSchema), extractProperties(revisionSchema))
+	baseProperties, _ := baseSchema["properties"].(map[string]interface{})
+	revisionProperties, _ := revisionSchema["properties"].(map[string]interface{})
+	for _, name := range sortedObjectKeys(baseProperties) {
+		if revisionProperty, ok := revisionProperties[name]; ok {
+			diff.diffInlineSchema(location+"."+name, baseProperties[name], revisionProperty)
+		}
+	}
+}
+
+// compare the properties of a schema, a removed property breaks the clients relying on it
+func (diff *SpecDiff) diffProperties(schemaLocation string, base []Property, revision []Property) {
+	revisionProperties := make(map[string]Property)
+	for _, property := range revision {
+		revisionProperties[property.Name] = property
+	}
+	baseProperties := make(map[string]bool)
+	for _, property := range base {
+		location := schemaLocation + "." + property.Name
+		baseProperties[property.Name] = true
+		revisionProperty, ok := revisionProperties[property.Name]
+		if !ok {
+			diff.add(Change{Kind: REMOVED_CHANGE, Element: PROPERTY_ELEMENT, Location: location, Breaking: true})
+			continue
+		}
+		diff.diffField(PROPERTY_ELEMENT, location, "type", property.Type, revisionProperty.Type)
+		diff.diffRequired(PROPERTY_ELEMENT, location, property.Required, revisionProperty.Required)
+		diff.diffEnum(PROPERTY_ELEMENT, location, property.Enum, revisionProperty.Enum)
+	}
+	for _, property := range revision {
+		if !baseProperties[property.Name] {
+			diff.diffAdded(PROPERTY_ELEMENT, schemaLocation+"."+property.Name, property.Required)
+		}
+	}
+}
+
+// a changed field breaks the clients relying on its former value
+func (diff *SpecDiff) diffField(element ChangeElement, location string, field string, from string, to string) {
+	if from != to {
+		diff.add(Change{Kind: CHANGED_CHANGE, Element: element, Location: location, Field: field,
+			From: from, To: to, Breaking: true})
+	}
+}
+
+// an element becoming required breaks the clients which omit it
+func (diff *SpecDiff) diffRequired(element ChangeElement, location string, from bool, to bool) {
+	if from != to {
+		diff.add(Change{Kind: CHANGED_CHANGE, Element: element, Location: location, Field: "required",
+			From: fmt.Sprintf("%v", from), To: fmt.Sprintf("%v", to), Breaking: to})
+	}
+}
+
+// a narrowed enum breaks the clients sending or expecting a removed value, a widened one is compatible, enums are
+// compared as sets so reordering the values changes nothing
+func (diff *SpecDiff) diffEnum(element ChangeElement, location string, from []string, to []string) {
+	allowed := make(map[string]bool)
+	for _, value := range to {
+		allowed[value] = true
+	}
+	listed := make(map[string]bool)
+	same := true
+	for _, value := range from {
+		listed[value] = true
+		same = same && allowed[value]
+	}
+	if same && len(listed) == len(allowed) {
+		return
+	}
+	// an enum being introduced narrows any value down to the listed ones
+	narrowed := len(from) == 0
+	for _, value := range from {
+		if !allowed[value] && len(to) > 0 {
+			narrowed = true
+		}
+	}
+	diff.add(Change{Kind: CHANGED_CHANGE, Element: element, Location: location, Field: "enum",
+		From: strings.Join(from, ", "), To: strings.Join(to, ", "), Breaking: narrowed})
+}
+
+// an added element breaks the clients which omit it only when it is required
+func (diff *SpecDiff) diffAdded(element ChangeElement, location string, required bool) {
+	change := Change{Kind: ADDED_CHANGE, Element: element, Location: location, Breaking: required}
+	if required {
+		change.Field, change.To = "required", "true"
+	}
+	diff.add(change)
+}
+
+// key of an operation, e.g. GET /pets
+func apiKey(api Api) string {
+	return fmt.Sprintf("%s %s", strings.ToUpper(api.Method), api.Path)
+}
+
+// key of a parameter, e.g. query limit
+func parameterKey(parameter Parameter) string {
+	return parameter.In + " " + parameter.Name
+}
+
+// schema of a response of an operation, nil when the response has no content
+func responseSchema(api Api, statusCode string) interface{} {
+	responses := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(api.ResponseInJson), &responses); err != nil {
+		return nil
+	}
+	response, _ := responses[statusCode].(map[string]interface{})
+	content, _ := response["content"].(map[string]interface{})
+	if mediaType, ok := preferredMediaType(content); ok {
+		return mediaType["schema"]
+	}
+	return nil
+}
+
+// schema of the request body of an operation, a referenced component name or a type, and whether it is required
+func requestBodySchema(api Api) (string, bool, bool) {
+	requestBody := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(api.RequestBodyInJson), &requestBody); err != nil || len(requestBody) == 0 {
+		return "", false, false
+	}
+	required, _ := requestBody["required"].(bool)
+	schemas := make([]string, 0)
+	if content, ok := requestBody["content"].(map[string]interface{}); ok {
+		for _, mediaType := range sortedObjectKeys(content) {
+			schema, _ := content[mediaType].(map[string]interface{})["schema"].(map[string]interface{})
+			if ref, ok := schema["$ref"].(string); ok {
+				schemas = append(schemas, strings.TrimPrefix(ref, "#/components/schemas/"))
+			} else if schemaType, ok := schema["type"].(string); ok {
+				schemas = append(schemas, schemaType)
+			}
+		}
+	}
+	return strings.Join(schemas, ", "), required, true
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+)
+
+// run the diff command, writing a changelog between a base and a revision of a spec
+func runDiffCommand(args []string) error {
+	flagSet := flag.NewFlagSet("diff", flag.ExitOnError)
+	diffLang := flagSet.String("lang", "en", "Language of the changelog.")
+	diffOutput := flagSet.String("out", "", "Output file of the changelog, printed when empty.")
+	flagSet.Parse(args)
+	if flagSet.NArg() != 2 {
+		return errors.New("usage: diff [-lang tag] [-out file] <base spec> <revision spec>")
+	}
+
+	langType, err := ParseLanguage(*diffLang)
+	if err != nil {
+		return err
+	}
+	analyzer, err := newSwaggerAnalyzer(langType)
+	if err != nil {
+		return err
+	}
+	diff, err := DiffSpecs(analyzer, flagSet.Arg(0), flagSet.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	changelog := NewChangelogRenderer(analyzer.terms).Render(diff)
+	if *diffOutput == "" {
+		fmt.Print(changelog)
+		return nil
+	}
+	return ioutil.WriteFile(*diffOutput, []byte(changelog), 0644)
+}
+
+// run the breaking command, failing when the revision of a spec breaks the clients of its base
+func runBreakingCommand(args []string) error {
+	flagSet := flag.NewFlagSet("breaking", flag.ExitOnError)
+	breakingLang := flagSet.String("lang", "en", "Language of the markdown report.")
+	breakingFormat := flagSet.String("format", "md", "Format of the report, md or json.")
+	breakingOutput := flagSet.String("out", "", "Output file of the report, printed when empty.")
+	flagSet.Parse(args)
+	if flagSet.NArg() != 2 {
+		return errors.New("usage: breaking [-format md|json] [-lang tag] [-out file] <base spec> <revision spec>")
+	}
+
+	langType, err := ParseLanguage(*breakingLang)
+	if err != nil {
+		return err
+	}
+	analyzer, err := newSwaggerAnalyzer(langType)
+	if err != nil {
+		return err
+	}
+	diff, err := DiffSpecs(analyzer, flagSet.Arg(0), flagSet.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	report := NewBreakingReport(diff)
+	var content string
+	switch *breakingFormat {
+	case "md", "markdown":
+		content = NewChangelogRenderer(analyzer.terms).RenderReport(report)
+	case "json":
+		reportJson, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			return err
+		}
+		content = string(reportJson) + "\n"
+	default:
+		return InvalidOutputFormat
+	}
+	if *breakingOutput == "" {
+		fmt.Print(content)
+	} else if err := ioutil.WriteFile(*breakingOutput, []byte(content), 0644); err != nil {
+		return err
+	}
+
+	if report.HasBreaking() {
+		return fmt.Errorf("%d breaking change(s), a %s version bump is required", len(report.Breaking),
+			report.RequiredBump)
+	}
+	return nil
+}
+
+// compare two specs read from local paths or web urls
+func DiffSpecs(analyzer *SwaggerAnalyzer, baseInput string, revisionInput string) (*SpecDiff, error) {
+	docs := make([]*Document, 0, 2)
+	for _, input := range []string{baseInput, revisionInput} {
+		content, err := NewSwaggerContentGetter(input, DetectContentSource(input)).GetContent()
+		if err != nil {
+			return nil, err
+		}
+		doc, err := analyzer.Extract(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", input, err)
+		}
+		docs = append(docs, doc)
+	}
+	return DiffDocuments(docs[0], docs[1]), nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// test DiffSpecs and the changelog of two versions of a spec
+func TestDiffSpecs(t *testing.T) {
+	analyzer := NewSwaggerAnalyzer(ENGLISH)
+	diff, err := DiffSpecs(analyzer, "testdata/petstore.json", "testdata/petstore_v2.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Test diff - classify breaking changes")
+	{
+		expected := map[string]bool{
+			"added parameter GET /pets query owner": true,
+			"removed operation GET /pets/{petId}":   true,
+			"changed property Pet.name":             true,
+			"changed property Order.status":         true,
+			"added response GET /pets 400":          false,
+			"added property Order.quantity":         false,
+		}
+		if len(diff.Changes) != len(expected) {
+			t.Errorf("expected %d changes, got %v", len(expected), diff.Changes)
+		}
+		for _, change := range diff.Changes {
+			key := string(change.Kind) + " " + string(change.Element) + " " + change.Location
+			breaking, ok := expected[key]
+			if !ok {
+				t.Errorf("unexpected change %s", key)
+			} else if breaking != change.Breaking {
+				t.Errorf("expected %s to be breaking: %v", key, breaking)
+			}
+		}
+	}
+
+	t.Log("Test diff - compare a spec with itself")
+	{
+		same, err := DiffSpecs(analyzer, "testdata/petstore.json", "testdata/petstore.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(same.Changes) != 0 {
+			t.Errorf("expected no change, got %v", same.Changes)
+		}
+		if !strings.Contains(NewChangelogRenderer(analyzer.terms).Render(same), "No changes") {
+			t.Errorf("the changelog does not state there is no change")
+		}
+	}
+
+	t.Log("Test diff - compare enums as sets")
+	{
+		extract := func(enum string) *Document {
+			doc, err := analyzer.Extract(`{"openapi": "3.0.0", "info": {"title": "T", "version": "1.0.0"}, "paths": {},
+				"components": {"schemas": {"Order": {"type": "object",
+					"properties": {"status": {"type": "string", "enum": ` + enum + `}}}}}}`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return doc
+		}
+		if reordered := DiffDocuments(extract(`["placed", "approved"]`), extract(`["approved", "placed"]`)); len(reordered.Changes) != 0 {
+			t.Errorf("reordering an enum should change nothing, got %v", reordered.Changes)
+		}
+		narrowed := DiffDocuments(extract(`["placed", "approved"]`), extract(`["approved", "approved"]`))
+		if len(narrowed.Changes) != 1 || !narrowed.Changes[0].Breaking {
+			t.Errorf("expected a breaking enum change, got %v", narrowed.Changes)
+		}
+	}
+
+	t.Log("Test diff - removed fields of inline response schemas are breaking")
+	{
+		extract := func(schema string) *Document {
+			doc, err := analyzer.Extract(`{"openapi": "3.0.0", "info": {"title": "T", "version": "1.0.0"},
+				"paths": {"/pets": {"get": {"responses": {"200": {"description": "ok",
+					"content": {"application/json": {"schema": ` + schema + `}}}}}}}}`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return doc
+		}
+		base := extract(`{"type": "array", "items": {"type": "object", "properties": {"id": {"type": "integer"},
+			"name": {"type": "string"}, "owner": {"type": "object", "properties": {"name": {"type": "string"}}}}}}`)
+		revision := extract(`{"type": "array", "items": {"type": "object", "properties": {"id": {"type": "integer"},
+			"owner": {"type": "object", "properties": {"email": {"type": "string"}}}}}}`)
+		expected := map[string]bool{
+			"removed property GET /pets 200[].name":       true,
+			"removed property GET /pets 200[].owner.name": true,
+			"added property GET /pets 200[].owner.email":  false,
+		}
+		changes := DiffDocuments(base, revision).Changes
+		if len(changes) != len(expected) {
+			t.Errorf("expected %d changes, got %v", len(expected), changes)
+		}
+		for _, change := range changes {
+			key := string(change.Kind) + " " + string(change.Element) + " " + change.Location
+			if breaking, ok := expected[key]; !ok || breaking != change.Breaking {
+				t.Errorf("unexpected change %s, breaking: %v", key, change.Breaking)
+			}
+		}
+	}
+
+	t.Log("Test diff - render the changelog")
+	{
+		changelog := NewChangelogRenderer(analyzer.terms).Render(diff)
+		for _, expected := range []string{"1.0.0 → 2.0.0", "## Breaking changes",
+			"|Changed|Property|Pet.name|Type : string → integer|"} {
+			if !strings.Contains(changelog, expected) {
+				t.Errorf("expected %q in the changelog", expected)
+			}
+		}
+	}
+}
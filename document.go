@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var componentRefPattern = regexp.MustCompile(`#/components/schemas/([^"/]+)`)
+
+// Document struct, holding the format independent content extracted from a swagger doc
+type Document struct {
+	Model        *Model
+	Components   []Component
+	Apis         []Api
+	Translations Translations
+	// deprecated elements listed in a deprecations section, no section is rendered when empty
+	Deprecations []DeprecatedElement
+	// class diagrams of the components overview section, no section is rendered when empty
+	ComponentDiagrams []ComponentDiagram
+
+	anchors map[string]string // unique anchor ids keyed by element, built on first use
+}
+
+// anchor id of an API, unique in the doc
+func (doc *Document) ApiAnchor(api Api) string {
+	return doc.anchor("op "+strings.ToUpper(api.Method)+" "+api.Path, GetApiAnchor(api))
+}
+
+// anchor id of a component, unique in the doc
+func (doc *Document) ComponentAnchor(componentName string) string {
+	return doc.anchor("component "+componentName, GetComponentAnchor(componentName))
+}
+
+// anchor id of a tag, unique in the doc
+func (doc *Document) TagAnchor(tagName string) string {
+	return doc.anchor("tag "+tagName, GetAnchor("tag", tagName))
+}
+
+// unique anchor id of an element, the base anchor of elements the doc does not hold
+func (doc *Document) anchor(key string, base string) string {
+	if doc.anchors == nil {
+		doc.anchors = doc.uniqueAnchors()
+	}
+	if anchor, ok := doc.anchors[key]; ok {
+		return anchor
+	}
+	return base
+}
+
+// anchor ids of the APIs, components and tags of the doc, colliding ones suffixed with -2, -3 and so on
+func (doc *Document) uniqueAnchors() map[string]string {
+	bases := make(map[string]string)
+	for _, api := range doc.Apis {
+		bases["op "+strings.ToUpper(api.Method)+" "+api.Path] = GetApiAnchor(api)
+	}
+	for _, component := range doc.Components {
+		bases["component "+component.Name] = GetComponentAnchor(component.Name)
+	}
+	tagNames, _ := doc.ApisByTag()
+	for _, tagName := range tagNames {
+		bases["tag "+tagName] = GetAnchor("tag", tagName)
+	}
+
+	// keys are visited in sorted order, so the same elements always get the same suffixes
+	keys := make([]string, 0, len(bases))
+	for key := range bases {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	anchors := make(map[string]string)
+	used := make(map[string]bool)
+	for _, key := range keys {
+		anchor := bases[key]
+		for suffix := 2; used[anchor]; suffix++ {
+			anchor = fmt.Sprintf("%s-%d", bases[key], suffix)
+		}
+		used[anchor] = true
+		anchors[key] = anchor
+	}
+	return anchors
+}
+
+// group APIs by their first tag, keeping the order of the tags declared in the doc
+func (doc *Document) ApisByTag() ([]string, map[string][]Api) {
+	tagNames := make([]string, 0, len(doc.Model.Tags))
+	groups := make(map[string][]Api)
+	for _, tag := range doc.Model.Tags {
+		tagNames = append(tagNames, tag.Name)
+		groups[tag.Name] = make([]Api, 0)
+	}
+
+	for _, api := range doc.Apis {
+		tagName := "default"
+		if len(api.Tags) > 0 {
+			tagName = api.Tags[0]
+		}
+		if _, ok := groups[tagName]; !ok {
+			tagNames = append(tagNames, tagName)
+		}
+		groups[tagName] = append(groups[tagName], api)
+	}
+	return tagNames, groups
+}
+
+// names of the components referenced by an API, in sorted order
+func (doc *Document) ApiComponentRefs(api Api) []string {
+	return findComponentRefs(api.RequestBodyInJson, api.ResponseInJson)
+}
+
+// names of the components referenced by a component, in sorted order
+func (doc *Document) ComponentRefs(component Component) []string {
+	refs := make([]string, 0)
+	for _, ref := range findComponentRefs(component.Code) {
+		if ref != component.Name {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// APIs referencing a component
+func (doc *Document) ComponentUsages(componentName string) []Api {
+	usages := make([]Api, 0)
+	for _, api := range doc.Apis {
+		for _, ref := range doc.ApiComponentRefs(api) {
+			if ref == componentName {
+				usages = append(usages, api)
+				break
+			}
+		}
+	}
+	return usages
+}
+
+// find the distinct component names referenced in json contents
+func findComponentRefs(jsonContents ...string) []string {
+	found := make(map[string]bool)
+	refs := make([]string, 0)
+	for _, jsonContent := range jsonContents {
+		for _, match := range componentRefPattern.FindAllStringSubmatch(jsonContent, -1) {
+			if !found[match[1]] {
+				found[match[1]] = true
+				refs = append(refs, match[1])
+			}
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
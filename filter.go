@@ -0,0 +1,259 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// FilterCriteria struct, criteria an operation is matched against, empty criteria are ignored
+type FilterCriteria struct {
+	Tags        []string
+	Paths       []string // path globs, * matches within a segment and ** across segments, e.g. /admin/**
+	Methods     []string
+	OperationId *regexp.Regexp
+	Deprecated  bool              // match deprecated operations
+	Extensions  map[string]string // vendor extensions, an empty value matches any value but false
+}
+
+// whether no criterion is set
+func (criteria FilterCriteria) IsEmpty() bool {
+	return len(criteria.Tags) == 0 && len(criteria.Paths) == 0 && len(criteria.Methods) == 0 &&
+		criteria.OperationId == nil && !criteria.Deprecated && len(criteria.Extensions) == 0
+}
+
+// results of every criterion set on an operation
+func (criteria FilterCriteria) results(apiPath string, method string, operation map[string]interface{}) []bool {
+	results := make([]bool, 0)
+	if len(criteria.Tags) > 0 {
+		tags, _ := operation["tags"].([]interface{})
+		matched := false
+		for _, tag := range tags {
+			for _, name := range criteria.Tags {
+				matched = matched || tag == name
+			}
+		}
+		results = append(results, matched)
+	}
+	if len(criteria.Paths) > 0 {
+		matched := false
+		for _, pattern := range criteria.Paths {
+			matched = matched || matchPathGlob(pattern, apiPath)
+		}
+		results = append(results, matched)
+	}
+	if len(criteria.Methods) > 0 {
+		matched := false
+		for _, name := range criteria.Methods {
+			matched = matched || strings.EqualFold(name, method)
+		}
+		results = append(results, matched)
+	}
+	if criteria.OperationId != nil {
+		operationId, _ := operation["operationId"].(string)
+		results = append(results, criteria.OperationId.MatchString(operationId))
+	}
+	if criteria.Deprecated {
+		deprecated, _ := operation["deprecated"].(bool)
+		results = append(results, deprecated)
+	}
+	for _, name := range sortedKeys(criteria.Extensions) {
+		value, ok := operation[name]
+		expected := criteria.Extensions[name]
+		results = append(results, ok && ((expected == "" && value != false) || fmt.Sprintf("%v", value) == expected))
+	}
+	return results
+}
+
+// whether an operation matches every criterion set
+func (criteria FilterCriteria) MatchesAll(apiPath string, method string, operation map[string]interface{}) bool {
+	for _, matched := range criteria.results(apiPath, method, operation) {
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// whether an operation matches any criterion set
+func (criteria FilterCriteria) MatchesAny(apiPath string, method string, operation map[string]interface{}) bool {
+	for _, matched := range criteria.results(apiPath, method, operation) {
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// match a path against a glob, segment by segment
+func matchPathGlob(pattern string, apiPath string) bool {
+	return matchSegments(strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(strings.Trim(apiPath, "/"), "/"))
+}
+
+// match path segments, ** matches zero or more segments
+func matchSegments(patterns []string, segments []string) bool {
+	if len(patterns) == 0 {
+		return len(segments) == 0
+	}
+	if patterns[0] == "**" {
+		for index := 0; index <= len(segments); index++ {
+			if matchSegments(patterns[1:], segments[index:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, err := path.Match(patterns[0], segments[0])
+	return err == nil && matched && matchSegments(patterns[1:], segments[1:])
+}
+
+// OperationFilter struct, keeping the operations matching every include criterion and no exclude criterion
+type OperationFilter struct {
+	Include FilterCriteria
+	Exclude FilterCriteria
+}
+
+// whether the filter keeps every operation
+func (filter *OperationFilter) IsEmpty() bool {
+	return filter == nil || (filter.Include.IsEmpty() && filter.Exclude.IsEmpty())
+}
+
+// whether an operation is kept
+func (filter *OperationFilter) Keep(apiPath string, method string, operation map[string]interface{}) bool {
+	if !filter.Include.IsEmpty() && !filter.Include.MatchesAll(apiPath, method, operation) {
+		return false
+	}
+	return filter.Exclude.IsEmpty() || !filter.Exclude.MatchesAny(apiPath, method, operation)
+}
+
+// remove the filtered operations from a model, then the components and tags no remaining operation uses
+func (filter *OperationFilter) Apply(model *Model) {
+	if filter.IsEmpty() {
+		return
+	}
+	usedTags := filter.operationTags(model)
+	for apiPath, pathItem := range model.Paths {
+		methods, ok := pathItem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		remaining := 0
+		for method, operation := range methods {
+			if !operationMethods[method] {
+				continue
+			}
+			if operation, ok := operation.(map[string]interface{}); ok && !filter.Keep(apiPath, method, operation) {
+				delete(methods, method)
+				continue
+			}
+			remaining++
+		}
+		if remaining == 0 {
+			delete(model.Paths, apiPath)
+		}
+	}
+
+	// components are kept when referenced by a remaining operation, directly or through other components
+	referenced := make(map[string]bool)
+	pending := findComponentRefs(marshalFiltered(model.Paths))
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if referenced[name] {
+			continue
+		}
+		referenced[name] = true
+		pending = append(pending, findComponentRefs(marshalFiltered(model.Components.Schemas[name]))...)
+	}
+	for name := range model.Components.Schemas {
+		if !referenced[name] {
+			delete(model.Components.Schemas, name)
+		}
+	}
+
+	// declared tags of removed operations are dropped when no remaining operation uses them
+	remainingTags := filter.operationTags(model)
+	tags := model.Tags[:0]
+	for _, tag := range model.Tags {
+		if remainingTags[tag.Name] || !usedTags[tag.Name] {
+			tags = append(tags, tag)
+		}
+	}
+	model.Tags = tags
+}
+
+// tags used by the operations of a model
+func (filter *OperationFilter) operationTags(model *Model) map[string]bool {
+	tags := make(map[string]bool)
+	for _, pathItem := range model.Paths {
+		methods, _ := pathItem.(map[string]interface{})
+		for method, operation := range methods {
+			operation, ok := operation.(map[string]interface{})
+			if !ok || !operationMethods[method] {
+				continue
+			}
+			operationTags, _ := operation["tags"].([]interface{})
+			for _, tag := range operationTags {
+				if name, ok := tag.(string); ok {
+					tags[name] = true
+				}
+			}
+		}
+	}
+	return tags
+}
+
+// json of a filtered value, searched for component references
+func marshalFiltered(value interface{}) string {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
+// parse filter criteria from comma separated lists, extensions are given as name[=value]
+func ParseFilterCriteria(tags string, paths string, methods string, operationId string,
+	deprecated bool, extensions string) (FilterCriteria, error) {
+	criteria := FilterCriteria{Tags: splitList(tags), Paths: splitList(paths), Methods: splitList(methods),
+		Deprecated: deprecated}
+	if operationId != "" {
+		pattern, err := regexp.Compile(operationId)
+		if err != nil {
+			return criteria, err
+		}
+		criteria.OperationId = pattern
+	}
+	for _, extension := range splitList(extensions) {
+		if criteria.Extensions == nil {
+			criteria.Extensions = make(map[string]string)
+		}
+		name, value := extension, ""
+		if index := strings.Index(extension, "="); index >= 0 {
+			name, value = extension[:index], extension[index+1:]
+		}
+		criteria.Extensions[name] = value
+	}
+	return criteria, nil
+}
+
+// split a comma separated list, dropping empty items
+func splitList(list string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// factory for OperationFilter
+func NewOperationFilter(include FilterCriteria, exclude FilterCriteria) *OperationFilter {
+	return &OperationFilter{Include: include, Exclude: exclude}
+}
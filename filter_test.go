@@ -0,0 +1,121 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// spec with internal and deprecated operations, Owner is only referenced through Pet
+const filterSpec = `{
+	"openapi": "3.0.0",
+	"info": {"title": "Filtered", "version": "1.0.0"},
+	"tags": [{"name": "pets"}, {"name": "admin"}, {"name": "unused"}],
+	"paths": {
+		"/pets": {
+			"get": {"operationId": "listPets", "tags": ["pets"], "responses": {"200": {"description": "ok",
+				"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}}}},
+			"post": {"operationId": "createPet", "tags": ["pets"], "deprecated": true,
+				"responses": {"201": {"description": "created"}}}
+		},
+		"/admin/users/{id}": {
+			"delete": {"operationId": "deleteUser", "tags": ["admin"], "x-internal": true,
+				"responses": {"204": {"description": "deleted",
+					"content": {"application/json": {"schema": {"type": "object", "$ref": "#/components/schemas/User"}}}}}}
+		}
+	},
+	"components": {"schemas": {
+		"Pet": {"type": "object", "required": [], "properties": {"owner": {"type": "object", "$ref": "#/components/schemas/Owner"}}},
+		"Owner": {"type": "object", "required": [], "properties": {"name": {"type": "string"}}},
+		"User": {"type": "object", "required": [], "properties": {"name": {"type": "string"}}}
+	}}
+}`
+
+// test FilterCriteria and matchPathGlob
+func TestFilterCriteria(t *testing.T) {
+	t.Log("Test filter - matchPathGlob")
+	{
+		for pattern, expected := range map[string]bool{"/admin/**": true, "/admin/*": false, "/*/users/*": true,
+			"/**/{id}": true, "/pets": false} {
+			if matchPathGlob(pattern, "/admin/users/{id}") != expected {
+				t.Errorf("%s should match: %v", pattern, expected)
+			}
+		}
+	}
+
+	t.Log("Test filter - every include criterion and any exclude criterion")
+	{
+		operation := map[string]interface{}{"operationId": "deleteUser", "tags": []interface{}{"admin"},
+			"x-internal": true}
+		criteria := FilterCriteria{Tags: []string{"admin"}, Methods: []string{"GET"}}
+		if criteria.MatchesAll("/admin", "delete", operation) || !criteria.MatchesAny("/admin", "delete", operation) {
+			t.Errorf("unexpected match of %+v", criteria)
+		}
+		criteria, err := ParseFilterCriteria("", "", "", "^delete", false, "x-internal=true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if criteria.OperationId == nil || !criteria.MatchesAll("/admin", "delete", operation) {
+			t.Errorf("unexpected criteria %+v", criteria)
+		}
+	}
+}
+
+// test Apply in OperationFilter through the analyzer
+func TestOperationFilter_Apply(t *testing.T) {
+	extract := func(filter *OperationFilter) *Document {
+		analyzer := NewSwaggerAnalyzer(ENGLISH)
+		analyzer.SetFilter(filter)
+		doc, err := analyzer.Extract(filterSpec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+	names := func(doc *Document) string {
+		names := make([]string, 0)
+		for _, api := range doc.Apis {
+			names = append(names, api.OperationId)
+		}
+		for _, component := range doc.Components {
+			names = append(names, component.Name)
+		}
+		for _, tag := range doc.Model.Tags {
+			names = append(names, "#"+tag.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	t.Log("Test filter - no filter keeps everything")
+	{
+		if result := names(extract(nil)); result != "deleteUser,listPets,createPet,Owner,Pet,User,#pets,#admin,#unused" {
+			t.Errorf("unexpected doc %s", result)
+		}
+	}
+
+	t.Log("Test filter - exclude internal and deprecated operations, pruning components transitively")
+	{
+		exclude := FilterCriteria{Deprecated: true, Extensions: map[string]string{"x-internal": ""}}
+		doc := extract(NewOperationFilter(FilterCriteria{}, exclude))
+		if result := names(doc); result != "listPets,Owner,Pet,#pets,#unused" {
+			t.Errorf("unexpected doc %s", result)
+		}
+
+		t.Log("Translations of the remaining tags refer to their position in the spec")
+		{
+			doc.Translations.Merge(Translations{CHINESE: {"/tags/1/description": "管理", "/tags/2/description": "未使用"}})
+			tags := doc.Localize(CHINESE).Model.Tags
+			if tags[0].Description != "" || tags[1].Description != "未使用" {
+				t.Errorf("unexpected tag descriptions %q and %q", tags[0].Description, tags[1].Description)
+			}
+		}
+	}
+
+	t.Log("Test filter - include by path and operation id")
+	{
+		include := FilterCriteria{Paths: []string{"/admin/**"}, OperationId: regexp.MustCompile("User$")}
+		if result := names(extract(NewOperationFilter(include, FilterCriteria{}))); result != "deleteUser,User,#admin,#unused" {
+			t.Errorf("unexpected doc %s", result)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// stylesheet embedded into every html document, so the page has no external assets
+const htmlStyleSheet = `
+* { box-sizing: border-box; }
+body { margin: 0; font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #24292e; line-height: 1.5; }
+nav.sidebar { position: fixed; top: 0; bottom: 0; left: 0; width: 280px; overflow-y: auto; padding: 16px; background: #f6f8fa; border-right: 1px solid #e1e4e8; }
+nav.sidebar h2 { font-size: 14px; text-transform: uppercase; color: #586069; margin: 16px 0 4px; }
+nav.sidebar h3 { font-size: 13px; margin: 8px 0 2px; }
+nav.sidebar ul { list-style: none; margin: 0; padding: 0; }
+nav.sidebar li { margin: 2px 0; font-size: 13px; }
+nav.sidebar a { color: #0366d6; text-decoration: none; }
+nav.sidebar a:hover { text-decoration: underline; }
+main { margin-left: 280px; padding: 24px 40px; max-width: 1100px; }
+main.index { margin-left: 0; }
+section { margin-bottom: 40px; }
+article { border: 1px solid #e1e4e8; border-radius: 6px; padding: 16px; margin: 16px 0; }
+table { border-collapse: collapse; width: 100%; margin: 8px 0; }
+th, td { border: 1px solid #dfe2e5; padding: 6px 12px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+pre { background: #f6f8fa; padding: 12px; overflow-x: auto; border-radius: 4px; }
+code { font-family: SFMono-Regular, Consolas, Menlo, monospace; font-size: 90%; }
+details summary { cursor: pointer; font-weight: 600; margin: 8px 0; }
+.method { display: inline-block; min-width: 64px; padding: 2px 8px; border-radius: 4px; color: #fff; font-size: 12px; font-weight: 700; text-align: center; text-transform: uppercase; }
+.method-get { background: #2f80ed; }
+.method-post { background: #27ae60; }
+.method-put { background: #f2994a; }
+.method-patch { background: #9b51e0; }
+.method-delete { background: #eb5757; }
+.method-head, .method-options, .method-trace { background: #828282; }
+.operation-id { color: #586069; font-size: 13px; }
+.tag { display: inline-block; background: #e1e4e8; border-radius: 10px; padding: 0 8px; margin-right: 4px; font-size: 12px; }
+.deprecated { display: inline-block; background: #eb5757; color: #fff; border-radius: 4px; padding: 0 6px; font-size: 12px; font-weight: 700; }
+.deprecation-details { color: #586069; font-size: 13px; }
+pre.mermaid { background: none; }
+.omitted { color: #586069; font-size: 13px; }
+`
+
+type HtmlRenderer struct {
+	terms map[string]string // terms associated with language settings
+}
+
+// render an extracted doc as a standalone html page
+func (renderer *HtmlRenderer) Render(doc *Document) (string, error) {
+	title := html.EscapeString(doc.Model.Info.Title)
+	page := "<!DOCTYPE html>\n<html>\n<head>\n"
+	page += "<meta charset=\"utf-8\">\n"
+	page += "<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n"
+	page += fmt.Sprintf("<title>%s</title>\n", title)
+	page += fmt.Sprintf("<style>%s</style>\n", htmlStyleSheet)
+	page += "</head>\n<body>\n"
+	page += renderer.FormatSidebar(doc)
+	page += "<main>\n"
+	page += fmt.Sprintf("<header>\n<h1>%s</h1>\n</header>\n", title)
+	page += renderer.FormatOverview(doc.Model)
+	if len(doc.ComponentDiagrams) > 0 {
+		page += renderer.FormatComponentDiagrams(doc.ComponentDiagrams)
+	}
+	page += renderer.FormatComponents(doc)
+	page += renderer.FormatPaths(doc)
+	if len(doc.Deprecations) > 0 {
+		page += renderer.FormatDeprecations(doc.Deprecations)
+	}
+	page += "</main>\n</body>\n</html>\n"
+	return page, nil
+}
+
+// format the sidebar navigation, linking to every section, component and API
+func (renderer *HtmlRenderer) FormatSidebar(doc *Document) string {
+	sidebar := "<nav class=\"sidebar\">\n"
+	sidebar += fmt.Sprintf("<h2><a href=\"#overview\">%s</a></h2>\n", renderer.term("overview"))
+
+	if len(doc.ComponentDiagrams) > 0 {
+		sidebar += fmt.Sprintf("<h2><a href=\"#components-overview\">%s</a></h2>\n",
+			renderer.term("components_overview"))
+	}
+	sidebar += fmt.Sprintf("<h2><a href=\"#components\">%s</a></h2>\n<ul>\n", renderer.term("components"))
+	for _, component := range doc.Components {
+		sidebar += fmt.Sprintf("<li><a href=\"#%s\">%s</a></li>\n",
+			doc.ComponentAnchor(component.Name), html.EscapeString(component.Name))
+	}
+	sidebar += "</ul>\n"
+
+	sidebar += fmt.Sprintf("<h2><a href=\"#paths\">%s</a></h2>\n", renderer.term("paths"))
+	tagNames, groups := doc.ApisByTag()
+	for _, tagName := range tagNames {
+		if len(groups[tagName]) == 0 {
+			continue
+		}
+		sidebar += fmt.Sprintf("<h3>%s</h3>\n<ul>\n", html.EscapeString(tagName))
+		for _, api := range groups[tagName] {
+			sidebar += fmt.Sprintf("<li><a href=\"#%s\">%s %s</a></li>\n",
+				doc.ApiAnchor(api), renderer.FormatMethodBadge(api.Method), html.EscapeString(api.Path))
+		}
+		sidebar += "</ul>\n"
+	}
+	if len(doc.Deprecations) > 0 {
+		sidebar += fmt.Sprintf("<h2><a href=\"#deprecations\">%s</a></h2>\n", renderer.term("deprecations"))
+	}
+	sidebar += "</nav>\n"
+	return sidebar
+}
+
+// format the overview section, including info, servers and tags
+func (renderer *HtmlRenderer) FormatOverview(swaggerModel *Model) string {
+	overview := fmt.Sprintf("<section id=\"overview\">\n<h2>%s</h2>\n", renderer.term("overview"))
+	overview += fmt.Sprintf("<p><strong>%s</strong></p>\n", html.EscapeString(swaggerModel.Info.Description))
+
+	overview += fmt.Sprintf("<h3>%s</h3>\n", renderer.term("contact"))
+	overview += renderer.formatStringMap(swaggerModel.Info.Contact)
+	overview += fmt.Sprintf("<h3>%s</h3>\n", renderer.term("license"))
+	overview += renderer.formatStringMap(swaggerModel.Info.License)
+	overview += fmt.Sprintf("<h3>%s</h3>\n<p>%s</p>\n", renderer.term("version"),
+		html.EscapeString(swaggerModel.Info.Version))
+
+	overview += fmt.Sprintf("<h3>%s</h3>\n<ul>\n", renderer.term("servers"))
+	for index, server := range swaggerModel.Servers {
+		overview += fmt.Sprintf("<li>%s<ul><li>%s : <code>%s</code></li><li>%s : %s</li></ul></li>\n",
+			html.EscapeString(LocalizeServerName(renderer.terms, index)), renderer.term("url"),
+			html.EscapeString(server.Url), renderer.term("description"), html.EscapeString(server.Description))
+	}
+	overview += "</ul>\n"
+
+	overview += fmt.Sprintf("<h3>%s</h3>\n<dl>\n", renderer.term("tags"))
+	for _, tag := range swaggerModel.Tags {
+		overview += fmt.Sprintf("<dt>%s</dt>\n<dd>%s</dd>\n",
+			html.EscapeString(tag.Name), html.EscapeString(tag.Description))
+	}
+	overview += "</dl>\n</section>\n"
+	return overview
+}
+
+// format the components section
+func (renderer *HtmlRenderer) FormatComponents(doc *Document) string {
+	content := fmt.Sprintf("<section id=\"components\">\n<h2>%s</h2>\n", renderer.term("components"))
+	for _, component := range doc.Components {
+		content += renderer.FormatComponent(doc, &component)
+	}
+	content += "</section>\n"
+	return content
+}
+
+// format a single component, the schema of which is collapsible
+func (renderer *HtmlRenderer) FormatComponent(doc *Document, component *Component) string {
+	content := fmt.Sprintf("<article id=\"%s\">\n<h3>%s</h3>\n",
+		doc.ComponentAnchor(component.Name), html.EscapeString(component.Name))
+	content += formatHtmlParagraphs(component.Description)
+	content += fmt.Sprintf("<p>%s : <code>%s</code></p>\n", renderer.term("type"), html.EscapeString(component.Type))
+
+	rows := make([][]string, 0, len(component.Properties))
+	for _, property := range component.Properties {
+		rows = append(rows, []string{renderer.FormatDeprecated(html.EscapeString(property.Name), property.Deprecation),
+			fmt.Sprintf("<em>%s</em>", html.EscapeString(property.Type)),
+			html.EscapeString(LocalizeBool(renderer.terms, property.Required)), html.EscapeString(property.Description),
+			html.EscapeString(property.Example)})
+	}
+	content += renderer.GetTable(componentTableHeader, rows)
+
+	content += fmt.Sprintf("<details>\n<summary>%s</summary>\n", renderer.term("json_representation"))
+	content += fmt.Sprintf("<pre><code>%s</code></pre>\n", html.EscapeString(component.Code))
+	content += "</details>\n</article>\n"
+	return content
+}
+
+// format the paths section
+func (renderer *HtmlRenderer) FormatPaths(doc *Document) string {
+	content := fmt.Sprintf("<section id=\"paths\">\n<h2>%s</h2>\n", renderer.term("paths"))
+	for _, api := range doc.Apis {
+		content += renderer.FormatAPI(doc, api)
+	}
+	content += "</section>\n"
+	return content
+}
+
+// format an API
+func (renderer *HtmlRenderer) FormatAPI(doc *Document, api Api) string {
+	content := fmt.Sprintf("<article id=\"%s\">\n", doc.ApiAnchor(api))
+	content += fmt.Sprintf("<h3>%s %s</h3>\n", renderer.FormatMethodBadge(api.Method),
+		renderer.FormatDeprecated(fmt.Sprintf("<code>%s</code>", html.EscapeString(api.Path)), api.Deprecation))
+	if api.Summary != "" {
+		content += fmt.Sprintf("<p class=\"summary\"><strong>%s</strong></p>\n", html.EscapeString(api.Summary))
+	}
+	content += formatHtmlParagraphs(api.Description)
+	content += fmt.Sprintf("<p class=\"operation-id\">%s : %s</p>\n", renderer.term("operation_id"),
+		html.EscapeString(api.OperationId))
+
+	if len(api.Parameters) > 0 {
+		rows := make([][]string, 0, len(api.Parameters))
+		for _, parameter := range api.Parameters {
+			rows = append(rows, []string{html.EscapeString(parameter.In),
+				renderer.FormatDeprecated(html.EscapeString(parameter.Name), parameter.Deprecation),
+				html.EscapeString(parameter.Description), html.EscapeString(parameter.Type)})
+		}
+		content += fmt.Sprintf("<h4>%s</h4>\n", renderer.term("parameters"))
+		content += renderer.GetTable(parameterTableHeader, rows)
+	}
+
+	if len(api.Responses) > 0 {
+		rows := make([][]string, 0, len(api.Responses))
+		for _, response := range api.Responses {
+			rows = append(rows, []string{html.EscapeString(response.StatusCode),
+				html.EscapeString(response.Description),
+				html.EscapeString(LocalizeSchema(renderer.terms, response.Schema))})
+		}
+		content += fmt.Sprintf("<h4>%s</h4>\n", renderer.term("responses"))
+		content += renderer.GetTable(responseTableHeader, rows)
+	}
+
+	if len(api.CodeSamples) > 0 {
+		content += fmt.Sprintf("<h4>%s</h4>\n", renderer.term("code_samples"))
+		for _, sample := range api.CodeSamples {
+			content += fmt.Sprintf("<p class=\"code-sample\">%s</p>\n<pre><code class=\"language-%s\">%s</code></pre>\n",
+				html.EscapeString(sample.Label), html.EscapeString(sample.Lang), html.EscapeString(sample.Source))
+		}
+	}
+
+	if len(api.Tags) > 0 {
+		content += fmt.Sprintf("<h4>%s</h4>\n<p>", renderer.term("tags"))
+		for _, tag := range api.Tags {
+			content += fmt.Sprintf("<span class=\"tag\">%s</span>", html.EscapeString(tag))
+		}
+		content += "</p>\n"
+	}
+
+	if api.Origin != "" {
+		content += fmt.Sprintf("<h4>%s</h4>\n<p class=\"origin\">%s</p>\n", renderer.term("origin"),
+			html.EscapeString(api.Origin))
+	}
+
+	content += "</article>\n"
+	return content
+}
+
+// format the components overview section, the diagrams are left as mermaid source for the page to stay
+// self-contained, tools running mermaid on pre.mermaid blocks draw them
+func (renderer *HtmlRenderer) FormatComponentDiagrams(diagrams []ComponentDiagram) string {
+	content := fmt.Sprintf("<section id=\"components-overview\">\n<h2>%s</h2>\n", renderer.term("components_overview"))
+	for _, diagram := range diagrams {
+		if diagram.Tag != "" {
+			content += fmt.Sprintf("<h3>%s</h3>\n", html.EscapeString(diagram.Tag))
+		}
+		content += fmt.Sprintf("<pre class=\"mermaid\">\n%s\n</pre>\n", html.EscapeString(diagram.Source))
+		if diagram.Omitted > 0 {
+			content += fmt.Sprintf("<p class=\"omitted\">%s</p>\n",
+				html.EscapeString(FormatOmittedComponents(renderer.terms, diagram.Omitted)))
+		}
+	}
+	content += "</section>\n"
+	return content
+}
+
+// format the deprecations section
+func (renderer *HtmlRenderer) FormatDeprecations(elements []DeprecatedElement) string {
+	content := fmt.Sprintf("<section id=\"deprecations\">\n<h2>%s</h2>\n", renderer.term("deprecations"))
+	content += renderer.GetTable(deprecationTableHeader, deprecationRows(renderer.terms, elements, html.EscapeString))
+	content += "</section>\n"
+	return content
+}
+
+// strike an escaped name through when deprecated, followed by a badge and the details of the deprecation
+func (renderer *HtmlRenderer) FormatDeprecated(name string, deprecation Deprecation) string {
+	if !deprecation.Deprecated {
+		return name
+	}
+	marked := fmt.Sprintf("<del>%s</del> <span class=\"deprecated\">%s</span>", name, renderer.term("deprecated"))
+	if details := FormatDeprecationDetails(renderer.terms, deprecation.DeprecationDetails); details != "" {
+		marked += fmt.Sprintf(" <span class=\"deprecation-details\">%s</span>", html.EscapeString(details))
+	}
+	return marked
+}
+
+// format the badge of an http method, colored by the method
+func (renderer *HtmlRenderer) FormatMethodBadge(method string) string {
+	lowerMethod := strings.ToLower(method)
+	return fmt.Sprintf("<span class=\"method method-%s\">%s</span>",
+		html.EscapeString(lowerMethod), html.EscapeString(strings.ToUpper(method)))
+}
+
+// format a text as escaped paragraphs, separated by blank lines in the text
+func formatHtmlParagraphs(text string) string {
+	content := ""
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			content += fmt.Sprintf("<p>%s</p>\n", html.EscapeString(paragraph))
+		}
+	}
+	return content
+}
+
+// generate a table with a localized header, the cells of which must have been escaped already
+func (renderer *HtmlRenderer) GetTable(header []string, rows [][]string) string {
+	table := "<table>\n<thead>\n<tr>"
+	for _, label := range LocalizeHeader(renderer.terms, header) {
+		table += fmt.Sprintf("<th>%s</th>", html.EscapeString(label))
+	}
+	table += "</tr>\n</thead>\n<tbody>\n"
+	for _, row := range rows {
+		table += "<tr>"
+		for _, cell := range row {
+			table += fmt.Sprintf("<td>%s</td>", cell)
+		}
+		table += "</tr>\n"
+	}
+	table += "</tbody>\n</table>\n"
+	return table
+}
+
+// format a string map as a list, ordered by keys
+func (renderer *HtmlRenderer) formatStringMap(values map[string]string) string {
+	content := "<ul>\n"
+	for _, key := range sortedKeys(values) {
+		content += fmt.Sprintf("<li>%s : %s</li>\n", html.EscapeString(key), html.EscapeString(values[key]))
+	}
+	content += "</ul>\n"
+	return content
+}
+
+// escaped term of the current language
+func (renderer *HtmlRenderer) term(key string) string {
+	return html.EscapeString(renderer.terms[key])
+}
+
+// factory for HtmlRenderer
+func NewHtmlRenderer(terms map[string]string) *HtmlRenderer {
+	return &HtmlRenderer{terms: terms}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// read the swagger doc shared by tests
+func readTestSpec(t *testing.T) string {
+	content, err := ioutil.ReadFile("testdata/petstore.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// test Render in HtmlRenderer
+func TestHtmlRenderer_Render(t *testing.T) {
+	t.Log("Test html renderer - Render")
+	{
+		analyzer := NewSwaggerAnalyzer(ENGLISH)
+		doc, err := analyzer.Extract(readTestSpec(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := NewHtmlRenderer(analyzer.terms).Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Log("Check the page is standalone and escaped")
+		{
+			for _, expected := range []string{
+				"<style>",
+				"<nav class=\"sidebar\">",
+				"A sample API for &lt;pets&gt; &amp; owners",
+				"<span class=\"method method-post\">POST</span>",
+				"<a href=\"#op-get-pets-petid\">",
+				"<article id=\"component-pet\">",
+				"<details>",
+				"array&lt;string&gt;",
+			} {
+				if !strings.Contains(result, expected) {
+					t.Errorf("expected %q in html output", expected)
+				}
+			}
+			for _, unexpected := range []string{"<link", "<script", "<pets>"} {
+				if strings.Contains(result, unexpected) {
+					t.Errorf("unexpected %q in html output", unexpected)
+				}
+			}
+		}
+
+		t.Log("Check the components overview keeps the page standalone")
+		{
+			doc.ComponentDiagrams = NewComponentDiagramBuilder(0).Build(doc)
+			result, err := NewHtmlRenderer(analyzer.terms).Render(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(result, "<pre class=\"mermaid\">\nclassDiagram\n") {
+				t.Errorf("expected a mermaid block in html output")
+			}
+			for _, unexpected := range []string{"<link", "<script", "http://", "https://cdn"} {
+				if strings.Contains(result, unexpected) {
+					t.Errorf("unexpected %q in html output", unexpected)
+				}
+			}
+		}
+	}
+}
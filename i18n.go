@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// vendor extension holding the translations of an object, e.g. x-i18n: {zh: {summary: ..., description: ...}}
+const I18N_EXTENSION = "x-i18n"
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Translations of spec texts: language -> JSON pointer of a text -> translated text
+type Translations map[LanguageType]map[string]string
+
+// generate a JSON pointer from unescaped reference tokens
+func JsonPointer(tokens ...string) string {
+	pointer := ""
+	for _, token := range tokens {
+		pointer += "/" + jsonPointerEscaper.Replace(token)
+	}
+	return pointer
+}
+
+// JSON pointer of an API operation
+func ApiPointer(api Api) string {
+	return JsonPointer("paths", api.Path, api.Method)
+}
+
+// add the translation of the text a pointer refers to
+func (translations Translations) Add(lang LanguageType, pointer string, text string) {
+	if _, ok := translations[lang]; !ok {
+		translations[lang] = make(map[string]string)
+	}
+	translations[lang][pointer] = text
+}
+
+// add the translations of an x-i18n extension found on the object a pointer refers to
+func (translations Translations) AddExtension(pointer string, extension map[string]map[string]string) {
+	for langName, fields := range extension {
+		lang, err := ParseLanguage(langName)
+		if err != nil {
+			continue
+		}
+		for field, text := range fields {
+			translations.Add(lang, pointer+"/"+jsonPointerEscaper.Replace(field), text)
+		}
+	}
+}
+
+// merge other translations, which take precedence over the existing ones
+func (translations Translations) Merge(other Translations) {
+	for lang, texts := range other {
+		for pointer, text := range texts {
+			translations.Add(lang, pointer, text)
+		}
+	}
+}
+
+// texts of a language, a regional language inherits the texts of its parent languages
+func (translations Translations) For(lang LanguageType) map[string]string {
+	texts := make(map[string]string)
+	subtags := strings.Split(string(lang), "-")
+	for length := 1; length <= len(subtags); length++ {
+		for pointer, text := range translations[LanguageType(strings.Join(subtags[:length], "-"))] {
+			texts[pointer] = text
+		}
+	}
+	return texts
+}
+
+// extract the translations given by x-i18n extensions on info, tags, operations, parameters,
+// responses, schemas and properties
+func ExtractTranslations(swaggerModel *Model) Translations {
+	translations := make(Translations)
+	translations.AddExtension("/info", swaggerModel.Info.I18n)
+	for _, tag := range swaggerModel.Tags {
+		translations.AddExtension(JsonPointer("tags", strconv.Itoa(tag.Index)), tag.I18n)
+	}
+
+	for apiPath, methods := range swaggerModel.Paths {
+		methodsJson, ok := methods.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for methodName, operation := range methodsJson {
+			operationJson, ok := operation.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			operationPointer := JsonPointer("paths", apiPath, methodName)
+			translations.addRawExtension(operationPointer, operationJson)
+			if parameters, ok := operationJson["parameters"].([]interface{}); ok {
+				for index, parameter := range parameters {
+					translations.addRawExtension(operationPointer+JsonPointer("parameters", strconv.Itoa(index)), parameter)
+				}
+			}
+			if responses, ok := operationJson["responses"].(map[string]interface{}); ok {
+				for statusCode, response := range responses {
+					translations.addRawExtension(operationPointer+JsonPointer("responses", statusCode), response)
+				}
+			}
+		}
+	}
+
+	for schemaName, schema := range swaggerModel.Components.Schemas {
+		schemaPointer := JsonPointer("components", "schemas", schemaName)
+		translations.addRawExtension(schemaPointer, schema)
+		if schemaJson, ok := schema.(map[string]interface{}); ok {
+			if properties, ok := schemaJson["properties"].(map[string]interface{}); ok {
+				for propertyName, property := range properties {
+					translations.addRawExtension(schemaPointer+JsonPointer("properties", propertyName), property)
+				}
+			}
+		}
+	}
+	return translations
+}
+
+// add the x-i18n extension of a raw json object
+func (translations Translations) addRawExtension(pointer string, object interface{}) {
+	objectJson, ok := object.(map[string]interface{})
+	if !ok {
+		return
+	}
+	extension, ok := objectJson[I18N_EXTENSION].(map[string]interface{})
+	if !ok {
+		return
+	}
+	fields := make(map[string]map[string]string)
+	for langName, langFields := range extension {
+		fields[langName] = make(map[string]string)
+		if langFieldsJson, ok := langFields.(map[string]interface{}); ok {
+			for field, text := range langFieldsJson {
+				if textString, ok := text.(string); ok {
+					fields[langName][field] = textString
+				}
+			}
+		}
+	}
+	translations.AddExtension(pointer, fields)
+}
+
+// load a translation catalog, a json file like {"zh": {"/info/description": "..."}}
+func LoadTranslationCatalog(path string) (Translations, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	catalog := make(map[string]map[string]string)
+	if err := json.Unmarshal(content, &catalog); err != nil {
+		return nil, err
+	}
+
+	translations := make(Translations)
+	for langName, texts := range catalog {
+		lang, err := ParseLanguage(langName)
+		if err != nil {
+			return nil, err
+		}
+		for pointer, text := range texts {
+			translations.Add(lang, pointer, text)
+		}
+	}
+	return translations, nil
+}
+
+// copy of the doc with the texts translated into a language, untranslated texts are kept
+func (doc *Document) Localize(lang LanguageType) *Document {
+	texts := doc.Translations.For(lang)
+	if len(texts) == 0 {
+		return doc
+	}
+	translate := func(pointer string, original string) string {
+		if text, ok := texts[pointer]; ok {
+			return text
+		}
+		return original
+	}
+
+	model := *doc.Model
+	model.Info.Title = translate("/info/title", model.Info.Title)
+	model.Info.Description = translate("/info/description", model.Info.Description)
+	model.Tags = append(model.Tags[:0:0], doc.Model.Tags...)
+	for index, tag := range model.Tags {
+		model.Tags[index].Description = translate(JsonPointer("tags", strconv.Itoa(tag.Index), "description"),
+			tag.Description)
+	}
+
+	localized := &Document{Model: &model, Translations: doc.Translations, Deprecations: doc.Deprecations,
+		ComponentDiagrams: doc.ComponentDiagrams}
+	localized.Apis = make([]Api, 0, len(doc.Apis))
+	for _, api := range doc.Apis {
+		pointer := ApiPointer(api)
+		api.Summary = translate(pointer+"/summary", api.Summary)
+		api.Description = translate(pointer+"/description", api.Description)
+		api.Parameters = append(api.Parameters[:0:0], api.Parameters...)
+		for index := range api.Parameters {
+			api.Parameters[index].Description = translate(pointer+JsonPointer("parameters", strconv.Itoa(index),
+				"description"), api.Parameters[index].Description)
+		}
+		api.Responses = append(api.Responses[:0:0], api.Responses...)
+		for index := range api.Responses {
+			api.Responses[index].Description = translate(pointer+JsonPointer("responses",
+				api.Responses[index].StatusCode, "description"), api.Responses[index].Description)
+		}
+		localized.Apis = append(localized.Apis, api)
+	}
+
+	localized.Components = make([]Component, 0, len(doc.Components))
+	for _, component := range doc.Components {
+		pointer := JsonPointer("components", "schemas", component.Name)
+		component.Description = translate(pointer+"/description", component.Description)
+		component.Properties = append(component.Properties[:0:0], component.Properties...)
+		for index := range component.Properties {
+			component.Properties[index].Description = translate(pointer+JsonPointer("properties",
+				component.Properties[index].Name, "description"), component.Properties[index].Description)
+		}
+		localized.Components = append(localized.Components, component)
+	}
+	return localized
+}
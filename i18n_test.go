@@ -0,0 +1,85 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// test Localize in Document with x-i18n extensions and a translation catalog
+func TestDocument_Localize(t *testing.T) {
+	t.Log("Test document - Localize")
+	{
+		doc, err := NewSwaggerAnalyzer(ENGLISH).Extract(readTestSpec(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Log("Merge a translation catalog")
+		{
+			catalogFile := filepath.Join(t.TempDir(), "catalog.json")
+			catalog := `{"zh": {"/paths/~1pets~1{petId}/get/responses/404/description": "找不到宠物"}}`
+			if err := ioutil.WriteFile(catalogFile, []byte(catalog), 0644); err != nil {
+				t.Fatal(err)
+			}
+			translations, err := LoadTranslationCatalog(catalogFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc.Translations.Merge(translations)
+		}
+
+		localized := doc.Localize("zh-CN")
+		if localized.Model.Info.Description != "宠物与主人的示例API" {
+			t.Errorf("info description not translated: %s", localized.Model.Info.Description)
+		}
+		if localized.Apis[0].Parameters[0].Description != "返回的条目数" {
+			t.Errorf("parameter description not translated: %s", localized.Apis[0].Parameters[0].Description)
+		}
+		if localized.Apis[2].Responses[1].Description != "找不到宠物" {
+			t.Errorf("response description not translated: %s", localized.Apis[2].Responses[1].Description)
+		}
+		if localized.Model.Tags[0].Description != "Everything about pets" {
+			t.Errorf("untranslated text should be kept: %s", localized.Model.Tags[0].Description)
+		}
+
+		t.Log("Translated component and property descriptions are rendered in every format")
+		{
+			analyzer := NewSwaggerAnalyzer(CHINESE)
+			templateRenderer, err := NewTemplateRenderer(analyzer.terms, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			renderers := []Renderer{templateRenderer}
+			for _, format := range []OutputFormat{MARKDOWN_FORMAT, HTML_FORMAT, ASCIIDOC_FORMAT, RST_FORMAT,
+				CONFLUENCE_FORMAT} {
+				renderer, err := NewRenderer(format, analyzer)
+				if err != nil {
+					t.Fatal(err)
+				}
+				renderers = append(renderers, renderer)
+			}
+			for _, renderer := range renderers {
+				result, err := renderer.Render(localized)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(result, "商店的宠物") || !strings.Contains(result, "宠物的名字") {
+					t.Errorf("%T does not render the component descriptions", renderer)
+				}
+			}
+		}
+
+		t.Log("The original doc is not changed")
+		{
+			if doc.Model.Info.Description != "A sample API for <pets> & owners" ||
+				doc.Apis[0].Parameters[0].Description != "How many items to return" {
+				t.Errorf("the original doc was modified")
+			}
+			if doc.Localize(ENGLISH) != doc {
+				t.Errorf("a doc without translations should be returned as is")
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// run the lang command, `lang check` validates every language pack against the canonical keys
+func runLangCommand(args []string) error {
+	if len(args) == 0 || args[0] != "check" {
+		return errors.New("usage: lang check [-lang-dir dir] [-lang-file file -lang tag]")
+	}
+
+	flagSet := flag.NewFlagSet("lang check", flag.ExitOnError)
+	checkLangDir := flagSet.String("lang-dir", "", "Directory of <tag>.json language packs to check.")
+	checkLangFile := flagSet.String("lang-file", "", "Language pack file to check as the language given by -lang.")
+	checkLang := flagSet.String("lang", "", "Language of the pack given by -lang-file.")
+	flagSet.Parse(args[1:])
+
+	fileLang := LanguageType("")
+	if *checkLangFile != "" {
+		parsedLang, err := ParseLanguage(*checkLang)
+		if err != nil {
+			return err
+		}
+		fileLang = parsedLang
+	}
+	if err := LoadLanguagePacks(*checkLangDir, *checkLangFile, fileLang); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, check := range defaultLanguageRegistry.CheckAll() {
+		fmt.Print(FormatLanguageCheck(check))
+		if !check.Ok() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d language pack(s) failed the check", failed)
+	}
+	return nil
+}
+
+// format the result of a language pack check for the console
+func FormatLanguageCheck(check LanguageCheck) string {
+	status := "ok"
+	if !check.Ok() {
+		status = "FAIL"
+	}
+	result := fmt.Sprintf("%s: %s\n", check.Lang, status)
+	if len(check.Missing) > 0 {
+		result += fmt.Sprintf("    missing: %s\n", strings.Join(check.Missing, ", "))
+	}
+	if len(check.Extra) > 0 {
+		result += fmt.Sprintf("    extra: %s\n", strings.Join(check.Extra, ", "))
+	}
+	if len(check.Untranslated) > 0 {
+		result += fmt.Sprintf("    untranslated: %s\n", strings.Join(check.Untranslated, ", "))
+	}
+	return result
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// BCP-47 style language tag, e.g. en, zh, zh-TW
+type LanguageType string
+
+const (
+	CHINESE LanguageType = "zh"
+	ENGLISH LanguageType = "en"
+
+	// the language every fallback chain ends with
+	DEFAULT_LANGUAGE = ENGLISH
+)
+
+// language packs shipped with the binary
+//
+//go:embed lang/*.json
+var embeddedLanguagePacks embed.FS
+
+// keys every language pack has to provide, directly or through its fallback chain
+var languageKeys = []string{
+	"overview", "version", "contact", "license", "servers", "uri_scheme", "tags",
+	"consumes", "produces", "paths", "parameters", "responses", "components",
+	"type", "name", "description", "schema", "http_code", "property_name", "property_type",
+	"required", "example", "true", "false", "no_schema", "no_content", "server", "url",
+	"properties", "json_representation", "references", "used_by",
+	"changelog", "breaking_changes", "non_breaking_changes", "no_changes", "change", "element", "location", "detail",
+	"added", "removed", "changed", "operation", "parameter", "request_body", "response", "component", "property",
+	"enum", "version_bump", "suggested_version", "index", "origin",
+	"deprecated", "deprecations", "sunset", "replacement", "operation_id", "code_samples",
+	"components_overview", "omitted_components",
+}
+
+var languageTagPattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// Invalid language tag, it's not a BCP-47 style tag
+var InvalidLanguageTag = errors.New("invalid language tag")
+
+// the registry all analyzers resolve their terms from
+var defaultLanguageRegistry = NewLanguageRegistry()
+
+// LanguagePack struct, the terms of a single language
+type LanguagePack struct {
+	Tag   LanguageType
+	Terms map[string]string
+}
+
+// LanguageRegistry struct, holding embedded and user supplied language packs
+type LanguageRegistry struct {
+	packs map[LanguageType]*LanguagePack
+}
+
+// parse and normalize a language tag, e.g. zh_tw becomes zh-TW
+func ParseLanguage(name string) (LanguageType, error) {
+	name = strings.Replace(name, "_", "-", -1)
+	if !languageTagPattern.MatchString(name) {
+		return "", fmt.Errorf("%v: %s", InvalidLanguageTag, name)
+	}
+
+	subtags := strings.Split(name, "-")
+	subtags[0] = strings.ToLower(subtags[0])
+	for index := 1; index < len(subtags); index++ {
+		switch len(subtags[index]) {
+		case 2:
+			subtags[index] = strings.ToUpper(subtags[index])
+		case 4:
+			subtags[index] = strings.ToUpper(subtags[index][:1]) + strings.ToLower(subtags[index][1:])
+		default:
+			subtags[index] = strings.ToLower(subtags[index])
+		}
+	}
+	return LanguageType(strings.Join(subtags, "-")), nil
+}
+
+// languages to look up terms in, from the most specific one to the default language
+func FallbackChain(lang LanguageType) []LanguageType {
+	chain := make([]LanguageType, 0)
+	subtags := strings.Split(string(lang), "-")
+	for length := len(subtags); length > 0; length-- {
+		chain = append(chain, LanguageType(strings.Join(subtags[:length], "-")))
+	}
+	if chain[len(chain)-1] != DEFAULT_LANGUAGE {
+		chain = append(chain, DEFAULT_LANGUAGE)
+	}
+	return chain
+}
+
+// register a language pack, replacing any pack of the same language
+func (registry *LanguageRegistry) Register(pack *LanguagePack) {
+	registry.packs[pack.Tag] = pack
+}
+
+// get the pack of a language
+func (registry *LanguageRegistry) Get(lang LanguageType) (*LanguagePack, bool) {
+	pack, ok := registry.packs[lang]
+	return pack, ok
+}
+
+// languages of all registered packs, in sorted order
+func (registry *LanguageRegistry) Languages() []LanguageType {
+	langs := make([]LanguageType, 0, len(registry.packs))
+	for lang := range registry.packs {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		return langs[i] < langs[j]
+	})
+	return langs
+}
+
+// load a language pack from a json file
+func (registry *LanguageRegistry) LoadFile(path string, lang LanguageType) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	pack, err := parseLanguagePack(content, lang)
+	if err != nil {
+		return fmt.Errorf("language pack %s: %v", path, err)
+	}
+	registry.Register(pack)
+	return nil
+}
+
+// load every <tag>.json language pack in a directory
+func (registry *LanguageRegistry) LoadDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		lang, err := ParseLanguage(strings.TrimSuffix(filepath.Base(file), ".json"))
+		if err != nil {
+			return fmt.Errorf("language pack %s: %v", file, err)
+		}
+		if err := registry.LoadFile(file, lang); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve the terms of a language by merging its fallback chain
+func (registry *LanguageRegistry) Resolve(lang LanguageType) (map[string]string, error) {
+	chain := FallbackChain(lang)
+	if !registry.hasOwnPack(chain) {
+		return nil, fmt.Errorf("no language pack for %s, available: %v", lang, registry.Languages())
+	}
+
+	terms := make(map[string]string)
+	for index := len(chain) - 1; index >= 0; index-- {
+		pack, ok := registry.packs[chain[index]]
+		if !ok {
+			continue
+		}
+		for key, value := range pack.Terms {
+			terms[key] = value
+		}
+	}
+
+	missing := registry.missingKeys(terms)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("language %s misses keys %v", lang, missing)
+	}
+	return terms, nil
+}
+
+// whether a pack exists in a fallback chain, not counting the default language unless it's requested
+func (registry *LanguageRegistry) hasOwnPack(chain []LanguageType) bool {
+	for index, lang := range chain {
+		if lang == DEFAULT_LANGUAGE && index > 0 {
+			continue
+		}
+		if _, ok := registry.packs[lang]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// canonical keys which are missing or empty in terms
+func (registry *LanguageRegistry) missingKeys(terms map[string]string) []string {
+	missing := make([]string, 0)
+	for _, key := range languageKeys {
+		if terms[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// decode a language pack
+func parseLanguagePack(content []byte, lang LanguageType) (*LanguagePack, error) {
+	pack := &LanguagePack{Tag: lang}
+	if err := json.Unmarshal(content, &pack.Terms); err != nil {
+		return nil, err
+	}
+	return pack, nil
+}
+
+// factory for LanguageRegistry, preloaded with the embedded language packs
+func NewLanguageRegistry() *LanguageRegistry {
+	registry := &LanguageRegistry{packs: make(map[LanguageType]*LanguagePack)}
+	files, err := embeddedLanguagePacks.ReadDir("lang")
+	if err != nil {
+		panic(err)
+	}
+	for _, file := range files {
+		content, err := embeddedLanguagePacks.ReadFile("lang/" + file.Name())
+		if err != nil {
+			panic(err)
+		}
+		pack, err := parseLanguagePack(content, LanguageType(strings.TrimSuffix(file.Name(), ".json")))
+		if err != nil {
+			panic(fmt.Sprintf("embedded language pack %s: %v", file.Name(), err))
+		}
+		registry.Register(pack)
+	}
+	return registry
+}
+
+// load user supplied language packs into the default registry
+func LoadLanguagePacks(dir string, file string, fileLang LanguageType) error {
+	if dir != "" {
+		if _, err := os.Stat(dir); err != nil {
+			return err
+		}
+		if err := defaultLanguageRegistry.LoadDir(dir); err != nil {
+			return err
+		}
+	}
+	if file != "" {
+		return defaultLanguageRegistry.LoadFile(file, fileLang)
+	}
+	return nil
+}
+
+// term key of a table header key, e.g. "HTTP Code" is looked up as http_code
+func headerTermKey(header string) string {
+	return strings.ToLower(strings.Replace(header, " ", "_", -1))
+}
+
+// labels of a table header in the language of terms
+func LocalizeHeader(terms map[string]string, header []string) []string {
+	labels := make([]string, 0, len(header))
+	for _, key := range header {
+		labels = append(labels, terms[headerTermKey(key)])
+	}
+	return labels
+}
+
+// a boolean in the language of terms
+func LocalizeBool(terms map[string]string, value bool) string {
+	if value {
+		return terms["true"]
+	}
+	return terms["false"]
+}
+
+// a response schema in the language of terms, an empty schema means the response has no content
+func LocalizeSchema(terms map[string]string, schema string) string {
+	if schema == "" {
+		return terms["no_schema"]
+	}
+	return schema
+}
+
+// name of the server at index in the language of terms
+func LocalizeServerName(terms map[string]string, index int) string {
+	return fmt.Sprintf("%s-%d", terms["server"], index)
+}
+
+// LanguageCheck struct, the problems found in a language pack
+type LanguageCheck struct {
+	Lang         LanguageType
+	Missing      []string // canonical keys provided neither by the pack nor by its parent languages
+	Extra        []string // keys the analyzer never looks up
+	Untranslated []string // keys whose value is the same as in the default language
+}
+
+// whether the pack can be used without printing empty terms
+func (check LanguageCheck) Ok() bool {
+	return len(check.Missing) == 0 && len(check.Extra) == 0
+}
+
+// check a registered language pack against the canonical keys
+func (registry *LanguageRegistry) Check(lang LanguageType) LanguageCheck {
+	check := LanguageCheck{Lang: lang, Missing: make([]string, 0), Extra: make([]string, 0),
+		Untranslated: make([]string, 0)}
+	pack, ok := registry.packs[lang]
+	if !ok {
+		check.Missing = append(check.Missing, languageKeys...)
+		return check
+	}
+
+	// parent languages may provide keys a regional pack leaves out, the default language may not
+	terms := make(map[string]string)
+	for _, chainLang := range FallbackChain(lang) {
+		if chainLang == DEFAULT_LANGUAGE && lang != DEFAULT_LANGUAGE {
+			continue
+		}
+		if chainPack, ok := registry.packs[chainLang]; ok {
+			for key, value := range chainPack.Terms {
+				if _, exists := terms[key]; !exists {
+					terms[key] = value
+				}
+			}
+		}
+	}
+	check.Missing = registry.missingKeys(terms)
+
+	canonical := make(map[string]bool)
+	for _, key := range languageKeys {
+		canonical[key] = true
+	}
+	defaultPack, hasDefault := registry.packs[DEFAULT_LANGUAGE]
+	for _, key := range sortedKeys(pack.Terms) {
+		if !canonical[key] {
+			check.Extra = append(check.Extra, key)
+		} else if lang != DEFAULT_LANGUAGE && hasDefault && pack.Terms[key] == defaultPack.Terms[key] {
+			check.Untranslated = append(check.Untranslated, key)
+		}
+	}
+	return check
+}
+
+// check every registered language pack
+func (registry *LanguageRegistry) CheckAll() []LanguageCheck {
+	checks := make([]LanguageCheck, 0, len(registry.packs))
+	for _, lang := range registry.Languages() {
+		checks = append(checks, registry.Check(lang))
+	}
+	return checks
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// test ParseLanguage and FallbackChain
+func TestParseLanguage(t *testing.T) {
+	t.Log("Test language - ParseLanguage")
+	{
+		cases := map[string]LanguageType{"en": "en", "ZH_tw": "zh-TW", "zh-hant-tw": "zh-Hant-TW"}
+		for name, expected := range cases {
+			lang, err := ParseLanguage(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if lang != expected {
+				t.Errorf("expected %s, got %s", expected, lang)
+			}
+		}
+		if _, err := ParseLanguage("../en"); err == nil {
+			t.Error("expected an error for an invalid tag")
+		}
+	}
+
+	t.Log("Test language - FallbackChain")
+	{
+		chain := FallbackChain("zh-Hant-TW")
+		expected := []LanguageType{"zh-Hant-TW", "zh-Hant", "zh", "en"}
+		if !reflect.DeepEqual(chain, expected) {
+			t.Errorf("expected %v, got %v", expected, chain)
+		}
+	}
+}
+
+// test Resolve in LanguageRegistry
+func TestLanguageRegistry_Resolve(t *testing.T) {
+	t.Log("Test language registry - Resolve a regional language through its fallback chain")
+	{
+		terms, err := NewLanguageRegistry().Resolve("zh-TW")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if terms["overview"] != "概述" {
+			t.Errorf("expected the zh term, got %s", terms["overview"])
+		}
+	}
+
+	t.Log("Test language registry - Resolve an unknown language")
+	{
+		if _, err := NewLanguageRegistry().Resolve("ja"); err == nil {
+			t.Error("expected an error for a language without pack")
+		}
+	}
+
+	t.Log("Test language registry - Resolve a user pack loaded from a directory")
+	{
+		langDir := t.TempDir()
+		pack := `{"overview": "概要", "paths": "パス"}`
+		if err := ioutil.WriteFile(filepath.Join(langDir, "ja.json"), []byte(pack), 0644); err != nil {
+			t.Fatal(err)
+		}
+		registry := NewLanguageRegistry()
+		if err := registry.LoadDir(langDir); err != nil {
+			t.Fatal(err)
+		}
+		terms, err := registry.Resolve("ja")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if terms["overview"] != "概要" || terms["components"] != "Components" {
+			t.Errorf("unexpected terms %v", terms)
+		}
+	}
+
+	t.Log("Test language registry - LoadFile with an invalid pack")
+	{
+		langFile := filepath.Join(t.TempDir(), "broken.json")
+		if err := ioutil.WriteFile(langFile, []byte(`{"overview": `), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := NewLanguageRegistry().LoadFile(langFile, "de"); err == nil {
+			t.Error("expected a decode error")
+		}
+	}
+}
+
+// test every generated string of a chinese doc is localized
+func TestSwaggerAnalyzer_AnalyzeChinese(t *testing.T) {
+	t.Log("Test swagger analyzer - Analyze in chinese")
+	{
+		result, err := NewSwaggerAnalyzer(CHINESE).Analyze(readTestSpec(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"|属性名|属性类型|是否必填|描述|示例|", "|HTTP状态码|描述|数据结构|", "无数据结构",
+			"+ 服务器-0", "+ 属性列表", "+ JSON表示"} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("expected %q in chinese output", expected)
+			}
+		}
+		for _, unexpected := range []string{"Property Name", "HTTP Code", "No schema", "Server-", "True", "False",
+			"JSON representation"} {
+			if strings.Contains(result, unexpected) {
+				t.Errorf("unexpected %q in chinese output", unexpected)
+			}
+		}
+	}
+}
+
+// test every embedded language pack passes the check
+func TestLanguageRegistry_CheckEmbedded(t *testing.T) {
+	t.Log("Test language registry - Check every embedded pack")
+	{
+		for _, check := range NewLanguageRegistry().CheckAll() {
+			if !check.Ok() || len(check.Untranslated) > 0 {
+				t.Errorf("language pack check failed:\n%s", FormatLanguageCheck(check))
+			}
+		}
+	}
+
+	t.Log("Test language registry - Check every looked up term is a canonical key")
+	{
+		canonical := make(map[string]bool)
+		for _, key := range languageKeys {
+			canonical[key] = true
+		}
+		lookup := regexp.MustCompile(`(?:terms\["|term\("|term ")([a-z_]+)"`)
+		files, err := filepath.Glob("*.go")
+		if err != nil {
+			t.Fatal(err)
+		}
+		templates, err := filepath.Glob("templates/*.tmpl")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range append(files, templates...) {
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, match := range lookup.FindAllStringSubmatch(string(content), -1) {
+				if !canonical[match[1]] {
+					t.Errorf("%s looks up %s which is not a canonical key", file, match[1])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,524 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type LintSeverity string
+
+const (
+	ERROR_SEVERITY   LintSeverity = "error"
+	WARNING_SEVERITY LintSeverity = "warning"
+	OFF_SEVERITY     LintSeverity = "off"
+)
+
+// structure rules of the OpenAPI 3.0/3.1 and Swagger 2.0 specifications
+const (
+	VALID_VERSION_RULE       = "valid-version"
+	REQUIRED_FIELDS_RULE     = "required-fields"
+	UNIQUE_OPERATION_ID_RULE = "unique-operation-id"
+	VALID_REF_RULE           = "valid-ref"
+	PATH_PARAMETERS_RULE     = "path-parameters"
+	VALID_STATUS_CODE_RULE   = "valid-status-code"
+)
+
+// style rules
+const (
+	DESCRIPTIONS_RULE          = "descriptions"
+	DECLARED_TAGS_RULE         = "declared-tags"
+	CAMEL_CASE_PROPERTIES_RULE = "camel-case-properties"
+	OPERATION_ID_RULE          = "operation-id"
+)
+
+// severities of the rules, structure rules are errors and style rules are warnings
+var defaultLintSeverities = map[string]LintSeverity{
+	VALID_VERSION_RULE:         ERROR_SEVERITY,
+	REQUIRED_FIELDS_RULE:       ERROR_SEVERITY,
+	UNIQUE_OPERATION_ID_RULE:   ERROR_SEVERITY,
+	VALID_REF_RULE:             ERROR_SEVERITY,
+	PATH_PARAMETERS_RULE:       ERROR_SEVERITY,
+	VALID_STATUS_CODE_RULE:     ERROR_SEVERITY,
+	DESCRIPTIONS_RULE:          WARNING_SEVERITY,
+	DECLARED_TAGS_RULE:         WARNING_SEVERITY,
+	CAMEL_CASE_PROPERTIES_RULE: WARNING_SEVERITY,
+	OPERATION_ID_RULE:          WARNING_SEVERITY,
+}
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+var (
+	pathTemplatePattern = regexp.MustCompile(`\{([^}]+)\}`)
+	statusCodePattern   = regexp.MustCompile(`^([1-5][0-9][0-9]|[1-5]XX|default)$`)
+	camelCasePattern    = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+)
+
+// Invalid lint config, it names an unknown rule or severity
+var InvalidLintConfig = errors.New("invalid lint config")
+
+// LintIssue struct, a rule violation located by the JSON pointer of the offending value
+type LintIssue struct {
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Pointer  string       `json:"pointer"`
+	Message  string       `json:"message"`
+}
+
+// Linter struct, validating a raw spec without assuming it is well formed
+type Linter struct {
+	Severities map[string]LintSeverity // severity per rule, off disables a rule
+
+	root         map[string]interface{}
+	version      string // 2.0, 3.0 or 3.1
+	operationIds map[string]string
+	issues       []LintIssue
+}
+
+// load a json config like {"rules": {"camel-case-properties": "off", "descriptions": "error"}}
+func (linter *Linter) LoadConfig(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	config := struct {
+		Rules map[string]LintSeverity `json:"rules"`
+	}{}
+	if err := json.Unmarshal(content, &config); err != nil {
+		return err
+	}
+	for rule, severity := range config.Rules {
+		if _, ok := defaultLintSeverities[rule]; !ok {
+			return fmt.Errorf("%w: unknown rule %s", InvalidLintConfig, rule)
+		}
+		if severity != ERROR_SEVERITY && severity != WARNING_SEVERITY && severity != OFF_SEVERITY {
+			return fmt.Errorf("%w: unknown severity %s of rule %s", InvalidLintConfig, severity, rule)
+		}
+		linter.Severities[rule] = severity
+	}
+	return nil
+}
+
+// lint a spec formatted in json, an error is returned only when it's not a json object
+func (linter *Linter) Lint(jsonInput string) ([]LintIssue, error) {
+	linter.root = nil
+	if err := json.Unmarshal([]byte(jsonInput), &linter.root); err != nil {
+		return nil, err
+	}
+	linter.issues = make([]LintIssue, 0)
+	linter.operationIds = make(map[string]string)
+
+	if !linter.lintVersion() {
+		return linter.issues, nil
+	}
+	linter.lintInfo()
+	linter.lintPaths()
+	linter.lintSchemas()
+	linter.lintRefs("", linter.root)
+	return linter.issues, nil
+}
+
+// detect the version of the specification
+func (linter *Linter) lintVersion() bool {
+	if swagger, ok := linter.root["swagger"]; ok {
+		if swagger == "2.0" {
+			linter.version = "2.0"
+			return true
+		}
+		linter.report(VALID_VERSION_RULE, "/swagger", "unsupported swagger version %v", swagger)
+		return false
+	}
+	openapi, _ := linter.root["openapi"].(string)
+	switch {
+	case strings.HasPrefix(openapi, "3.0."):
+		linter.version = "3.0"
+	case strings.HasPrefix(openapi, "3.1."):
+		linter.version = "3.1"
+	case openapi == "":
+		linter.report(VALID_VERSION_RULE, "", "missing openapi or swagger version")
+		return false
+	default:
+		linter.report(VALID_VERSION_RULE, "/openapi", "unsupported openapi version %s", openapi)
+		return false
+	}
+	return true
+}
+
+// check the info object has a title, a version and a description
+func (linter *Linter) lintInfo() {
+	info, ok := linter.root["info"].(map[string]interface{})
+	if !ok {
+		linter.report(REQUIRED_FIELDS_RULE, "", "missing info")
+		return
+	}
+	linter.requireString("/info", info, "title")
+	linter.requireString("/info", info, "version")
+	if _, ok := info["description"].(string); !ok {
+		linter.report(DESCRIPTIONS_RULE, "/info", "missing description")
+	}
+}
+
+// check every path item and the operations it holds
+func (linter *Linter) lintPaths() {
+	paths, ok := linter.root["paths"].(map[string]interface{})
+	if !ok {
+		// 3.1 documents may only hold components or webhooks
+		_, hasComponents := linter.root["components"]
+		_, hasWebhooks := linter.root["webhooks"]
+		if linter.version != "3.1" || !(hasComponents || hasWebhooks) {
+			linter.report(REQUIRED_FIELDS_RULE, "", "missing paths")
+		}
+		return
+	}
+
+	for _, apiPath := range sortedObjectKeys(paths) {
+		pathPointer := JsonPointer("paths", apiPath)
+		pathItem, ok := paths[apiPath].(map[string]interface{})
+		if !ok {
+			linter.report(REQUIRED_FIELDS_RULE, pathPointer, "path item must be an object")
+			continue
+		}
+		if !strings.HasPrefix(apiPath, "/") {
+			linter.report(REQUIRED_FIELDS_RULE, pathPointer, "path must begin with a slash")
+		}
+		pathParameters := linter.lintParameters(pathPointer, pathItem)
+		for _, method := range httpMethods {
+			if operation, ok := pathItem[method].(map[string]interface{}); ok {
+				linter.lintOperation(pathPointer+JsonPointer(method), apiPath, operation, pathParameters)
+			}
+		}
+	}
+}
+
+// check an operation, its id, parameters, request body and responses
+func (linter *Linter) lintOperation(pointer string, apiPath string, operation map[string]interface{},
+	pathParameters map[string]bool) {
+	if operationId, ok := operation["operationId"].(string); ok {
+		if first, ok := linter.operationIds[operationId]; ok {
+			linter.report(UNIQUE_OPERATION_ID_RULE, pointer+"/operationId",
+				"operationId %s is already used by %s", operationId, first)
+		} else {
+			linter.operationIds[operationId] = pointer
+		}
+	} else {
+		linter.report(OPERATION_ID_RULE, pointer, "missing operationId")
+	}
+
+	summary, _ := operation["summary"].(string)
+	description, _ := operation["description"].(string)
+	if summary == "" && description == "" {
+		linter.report(DESCRIPTIONS_RULE, pointer, "missing summary and description")
+	}
+	linter.lintTags(pointer, operation)
+
+	// operation parameters override the path parameters of the same name
+	declared := make(map[string]bool)
+	for name := range pathParameters {
+		declared[name] = true
+	}
+	for name := range linter.lintParameters(pointer, operation) {
+		declared[name] = true
+	}
+	templated := make(map[string]bool)
+	for _, match := range pathTemplatePattern.FindAllStringSubmatch(apiPath, -1) {
+		templated[match[1]] = true
+		if !declared[match[1]] {
+			linter.report(PATH_PARAMETERS_RULE, pointer, "path parameter %s is not declared", match[1])
+		}
+	}
+	for _, name := range sortedBoolKeys(declared) {
+		if !templated[name] {
+			linter.report(PATH_PARAMETERS_RULE, pointer, "path parameter %s is not in the path template", name)
+		}
+	}
+
+	if requestBody, ok := operation["requestBody"].(map[string]interface{}); ok && linter.version != "2.0" {
+		if _, isRef := requestBody["$ref"]; !isRef {
+			if _, ok := requestBody["content"].(map[string]interface{}); !ok {
+				linter.report(REQUIRED_FIELDS_RULE, pointer+"/requestBody", "missing content")
+			}
+		}
+	}
+	linter.lintResponses(pointer, operation)
+}
+
+// lint the parameters of a path item or an operation, returning the names of its path parameters
+func (linter *Linter) lintParameters(pointer string, object map[string]interface{}) map[string]bool {
+	pathParameters := make(map[string]bool)
+	parameters, ok := object["parameters"].([]interface{})
+	if !ok {
+		return pathParameters
+	}
+	for index, value := range parameters {
+		parameterPointer := pointer + JsonPointer("parameters", strconv.Itoa(index))
+		parameter, ok := linter.resolve(value)
+		if !ok {
+			continue
+		}
+		name, hasName := parameter["name"].(string)
+		in, hasIn := parameter["in"].(string)
+		if !hasName {
+			linter.report(REQUIRED_FIELDS_RULE, parameterPointer, "missing name")
+		}
+		if !hasIn {
+			linter.report(REQUIRED_FIELDS_RULE, parameterPointer, "missing in")
+			continue
+		}
+
+		validIn := "query header path cookie"
+		if linter.version == "2.0" {
+			validIn = "query header path formData body"
+		}
+		if !strings.Contains(" "+validIn+" ", " "+in+" ") {
+			linter.report(REQUIRED_FIELDS_RULE, parameterPointer+"/in", "invalid location %s", in)
+		}
+		if in == "path" {
+			pathParameters[name] = true
+			if required, _ := parameter["required"].(bool); !required {
+				linter.report(REQUIRED_FIELDS_RULE, parameterPointer, "path parameter %s must be required", name)
+			}
+		}
+
+		_, hasSchema := parameter["schema"]
+		_, hasContent := parameter["content"]
+		_, hasType := parameter["type"]
+		switch {
+		case linter.version != "2.0" && !hasSchema && !hasContent:
+			linter.report(REQUIRED_FIELDS_RULE, parameterPointer, "missing schema or content")
+		case linter.version == "2.0" && in == "body" && !hasSchema:
+			linter.report(REQUIRED_FIELDS_RULE, parameterPointer, "missing schema")
+		case linter.version == "2.0" && in != "body" && !hasType:
+			linter.report(REQUIRED_FIELDS_RULE, parameterPointer, "missing type")
+		}
+		if _, ok := parameter["description"].(string); !ok {
+			linter.report(DESCRIPTIONS_RULE, parameterPointer, "missing description")
+		}
+	}
+	return pathParameters
+}
+
+// check the responses of an operation
+func (linter *Linter) lintResponses(pointer string, operation map[string]interface{}) {
+	responses, ok := operation["responses"].(map[string]interface{})
+	if !ok || len(responses) == 0 {
+		// responses are optional since 3.1
+		if linter.version != "3.1" {
+			linter.report(REQUIRED_FIELDS_RULE, pointer, "missing responses")
+		}
+		return
+	}
+	for _, statusCode := range sortedObjectKeys(responses) {
+		responsePointer := pointer + JsonPointer("responses", statusCode)
+		if !statusCodePattern.MatchString(statusCode) || (linter.version == "2.0" && strings.HasSuffix(statusCode, "XX")) {
+			linter.report(VALID_STATUS_CODE_RULE, responsePointer, "invalid status code %s", statusCode)
+		}
+		if response, ok := linter.resolve(responses[statusCode]); ok {
+			linter.requireString(responsePointer, response, "description")
+		}
+	}
+}
+
+// every operation tag should be declared in the top level tags
+func (linter *Linter) lintTags(pointer string, operation map[string]interface{}) {
+	declared := make(map[string]bool)
+	if tags, ok := linter.root["tags"].([]interface{}); ok {
+		for _, tag := range tags {
+			if tagObject, ok := tag.(map[string]interface{}); ok {
+				if name, ok := tagObject["name"].(string); ok {
+					declared[name] = true
+				}
+			}
+		}
+	}
+	tags, _ := operation["tags"].([]interface{})
+	for index, tag := range tags {
+		if name, ok := tag.(string); ok && !declared[name] {
+			linter.report(DECLARED_TAGS_RULE, pointer+JsonPointer("tags", strconv.Itoa(index)),
+				"tag %s is not declared", name)
+		}
+	}
+}
+
+// lint the components schemas, or the definitions of a 2.0 document
+func (linter *Linter) lintSchemas() {
+	pointer := "/components/schemas"
+	components, _ := linter.root["components"].(map[string]interface{})
+	schemas, _ := components["schemas"].(map[string]interface{})
+	if linter.version == "2.0" {
+		pointer = "/definitions"
+		schemas, _ = linter.root["definitions"].(map[string]interface{})
+	}
+	for _, name := range sortedObjectKeys(schemas) {
+		schema, ok := schemas[name].(map[string]interface{})
+		if !ok {
+			linter.report(REQUIRED_FIELDS_RULE, pointer+JsonPointer(name), "schema must be an object")
+			continue
+		}
+		if _, isRef := schema["$ref"]; !isRef {
+			if _, ok := schema["description"].(string); !ok {
+				linter.report(DESCRIPTIONS_RULE, pointer+JsonPointer(name), "missing description")
+			}
+		}
+		linter.lintProperties(pointer+JsonPointer(name), schema)
+	}
+}
+
+// property names should be camelCase, nested objects and array items included
+func (linter *Linter) lintProperties(pointer string, schema map[string]interface{}) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, name := range sortedObjectKeys(properties) {
+		propertyPointer := pointer + JsonPointer("properties", name)
+		if !camelCasePattern.MatchString(name) {
+			linter.report(CAMEL_CASE_PROPERTIES_RULE, propertyPointer, "property %s is not camelCase", name)
+		}
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			linter.lintProperties(propertyPointer, property)
+		}
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		linter.lintProperties(pointer+"/items", items)
+	}
+}
+
+// every local $ref should point to an existing value, external refs are not followed
+func (linter *Linter) lintRefs(pointer string, value interface{}) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedObjectKeys(typed) {
+			if ref, ok := typed[key].(string); ok && key == "$ref" {
+				if strings.HasPrefix(ref, "#") {
+					if _, found := ResolveJsonPointer(linter.root, strings.TrimPrefix(ref, "#")); !found {
+						linter.report(VALID_REF_RULE, pointer+"/$ref", "unresolved reference %s", ref)
+					}
+				}
+				continue
+			}
+			linter.lintRefs(pointer+JsonPointer(key), typed[key])
+		}
+	case []interface{}:
+		for index, item := range typed {
+			linter.lintRefs(pointer+JsonPointer(strconv.Itoa(index)), item)
+		}
+	}
+}
+
+// resolve an object which may be a local reference
+func (linter *Linter) resolve(value interface{}) (map[string]interface{}, bool) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if ref, ok := object["$ref"].(string); ok {
+		resolved, found := ResolveJsonPointer(linter.root, strings.TrimPrefix(ref, "#"))
+		if !found {
+			return nil, false
+		}
+		object, ok = resolved.(map[string]interface{})
+	}
+	return object, ok
+}
+
+// report a missing or empty string field of an object
+func (linter *Linter) requireString(pointer string, object map[string]interface{}, field string) {
+	if value, ok := object[field].(string); !ok || value == "" {
+		linter.report(REQUIRED_FIELDS_RULE, pointer, "missing %s", field)
+	}
+}
+
+// record an issue of a rule, unless the rule is turned off
+func (linter *Linter) report(rule string, pointer string, format string, args ...interface{}) {
+	severity := linter.Severities[rule]
+	if severity == OFF_SEVERITY || severity == "" {
+		return
+	}
+	linter.issues = append(linter.issues, LintIssue{Rule: rule, Severity: severity, Pointer: pointer,
+		Message: fmt.Sprintf(format, args...)})
+}
+
+// resolve a JSON pointer like /components/schemas/Pet in a decoded json document
+func ResolveJsonPointer(document interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return document, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	current := document
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch typed := current.(type) {
+		case map[string]interface{}:
+			value, ok := typed[token]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(typed) {
+				return nil, false
+			}
+			current = typed[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// whether an error level issue was reported
+func HasLintErrors(issues []LintIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == ERROR_SEVERITY {
+			return true
+		}
+	}
+	return false
+}
+
+// format lint issues for the console, one issue per line
+func FormatLintIssues(issues []LintIssue) string {
+	result := ""
+	for _, issue := range issues {
+		pointer := issue.Pointer
+		if pointer == "" {
+			pointer = "(root)"
+		}
+		result += fmt.Sprintf("%-7s %s: %s [%s]\n", issue.Severity, pointer, issue.Message, issue.Rule)
+	}
+	return result
+}
+
+// keys of a json object in sorted order
+func sortedObjectKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// keys of a set in sorted order
+func sortedBoolKeys(values map[string]bool) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// factory for Linter with the default severities
+func NewLinter() *Linter {
+	linter := &Linter{Severities: make(map[string]LintSeverity)}
+	for rule, severity := range defaultLintSeverities {
+		linter.Severities[rule] = severity
+	}
+	return linter
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+)
+
+// run the lint command, failing when a spec violates an error level rule
+func runLintCommand(args []string) error {
+	flagSet := flag.NewFlagSet("lint", flag.ExitOnError)
+	lintConfig := flagSet.String("config", "",
+		"Json config of rule severities, like {\"rules\": {\"camel-case-properties\": \"off\"}}.")
+	lintFormat := flagSet.String("format", "text", "Format of the report, text or json.")
+	flagSet.Parse(args)
+	if flagSet.NArg() != 1 {
+		return errors.New("usage: lint [-config file] [-format text|json] <spec>")
+	}
+
+	linter := NewLinter()
+	if *lintConfig != "" {
+		if err := linter.LoadConfig(*lintConfig); err != nil {
+			return err
+		}
+	}
+	input := flagSet.Arg(0)
+	content, err := NewSwaggerContentGetter(input, DetectContentSource(input)).GetContent()
+	if err != nil {
+		return err
+	}
+	issues, err := linter.Lint(content)
+	if err != nil {
+		return fmt.Errorf("%s: %v", input, err)
+	}
+
+	switch *lintFormat {
+	case "text":
+		fmt.Print(FormatLintIssues(issues))
+	case "json":
+		issuesJson, err := json.MarshalIndent(issues, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(issuesJson))
+	default:
+		return InvalidOutputFormat
+	}
+
+	if HasLintErrors(issues) {
+		return fmt.Errorf("%s violates the openapi specification", input)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+const brokenSpec = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Broken", "description": "A broken spec"},
+	"tags": [{"name": "pets"}],
+	"paths": {
+		"/pets/{petId}": {
+			"get": {
+				"operationId": "getPet",
+				"summary": "Get a pet",
+				"tags": ["animals"],
+				"responses": {
+					"200": {"description": "A pet", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Cat"}}}},
+					"2000": {"description": "Not a status code"}
+				}
+			},
+			"delete": {
+				"operationId": "getPet",
+				"summary": "Delete a pet",
+				"parameters": [{"name": "petId", "in": "path", "required": true, "description": "Id", "schema": {"type": "string"}}],
+				"responses": {"204": {}}
+			}
+		}
+	},
+	"components": {
+		"schemas": {
+			"Pet": {"type": "object", "description": "A pet", "properties": {"pet_name": {"type": "string"}}}
+		}
+	}
+}`
+
+// test Lint in Linter
+func TestLinter_Lint(t *testing.T) {
+	t.Log("Test linter - Lint a valid spec")
+	{
+		issues, err := NewLinter().Lint(readTestSpec(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if HasLintErrors(issues) {
+			t.Errorf("unexpected errors:\n%s", FormatLintIssues(issues))
+		}
+	}
+
+	t.Log("Test linter - Lint a broken spec")
+	{
+		issues, err := NewLinter().Lint(brokenSpec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]string{
+			REQUIRED_FIELDS_RULE + " /info": "missing version",
+			VALID_REF_RULE + " /paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema/$ref": "unresolved reference #/components/schemas/Cat",
+			VALID_STATUS_CODE_RULE + " /paths/~1pets~1{petId}/get/responses/2000":                              "invalid status code 2000",
+			PATH_PARAMETERS_RULE + " /paths/~1pets~1{petId}/get":                                               "path parameter petId is not declared",
+			DECLARED_TAGS_RULE + " /paths/~1pets~1{petId}/get/tags/0":                                          "tag animals is not declared",
+			UNIQUE_OPERATION_ID_RULE + " /paths/~1pets~1{petId}/delete/operationId":                            "operationId getPet is already used by /paths/~1pets~1{petId}/get",
+			REQUIRED_FIELDS_RULE + " /paths/~1pets~1{petId}/delete/responses/204":                              "missing description",
+			CAMEL_CASE_PROPERTIES_RULE + " /components/schemas/Pet/properties/pet_name":                        "property pet_name is not camelCase",
+		}
+		found := make(map[string]string)
+		for _, issue := range issues {
+			found[issue.Rule+" "+issue.Pointer] = issue.Message
+		}
+		for key, message := range expected {
+			if found[key] != message {
+				t.Errorf("expected %s: %s, got %q", key, message, found[key])
+			}
+		}
+		if len(issues) != len(expected) {
+			t.Errorf("expected %d issues, got:\n%s", len(expected), FormatLintIssues(issues))
+		}
+	}
+
+	t.Log("Test linter - LoadConfig turning off a style rule")
+	{
+		configFile := filepath.Join(t.TempDir(), "lint.json")
+		config := `{"rules": {"camel-case-properties": "off", "declared-tags": "error"}}`
+		if err := ioutil.WriteFile(configFile, []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+		linter := NewLinter()
+		if err := linter.LoadConfig(configFile); err != nil {
+			t.Fatal(err)
+		}
+		issues, err := linter.Lint(brokenSpec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, issue := range issues {
+			if issue.Rule == CAMEL_CASE_PROPERTIES_RULE {
+				t.Errorf("the camel case rule should be off")
+			}
+			if issue.Rule == DECLARED_TAGS_RULE && issue.Severity != ERROR_SEVERITY {
+				t.Errorf("the declared tags rule should be an error")
+			}
+		}
+	}
+}
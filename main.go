@@ -1,11 +1,16 @@
 package main
 
 import (
-	"bufio"
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"log"
 	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
 )
 
 var (
@@ -13,32 +18,264 @@ var (
 	webInput string
 	lang string
 	output string
+	format string
+	templateDir string
+	langDir string
+	langFile string
+	languageSwitcher bool
+	translations string
+	layout string
+	splitComponents bool
+	watch bool
+	watchInterval time.Duration
+	jobs int
+	merge bool
+	mergeTitle string
+	includeTags, excludeTags string
+	includePaths, excludePaths string
+	includeMethods, excludeMethods string
+	includeOperationId, excludeOperationId string
+	includeExtensions, excludeExtensions string
+	excludeDeprecated bool
+	deprecations bool
+	codeSamples string
+	curlExamples bool
+	classDiagram bool
+	diagramLimit int
 )
 
+// commands selected by the first argument, any other arguments run a conversion
+var commands = map[string]func(args []string) error{
+	"lang": runLangCommand,
+	"diff": runDiffCommand,
+	"breaking": runBreakingCommand,
+	"lint": runLintCommand,
+	"serve": runServeCommand,
+	"preview": runPreviewCommand,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+	if err := runConvert(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	flag.StringVar(&localInput, "local", "./", "Local path of the input json.")
-	flag.StringVar(&webInput, "web", "", "Web url of the input json.")
-	flag.StringVar(&lang, "lang", "en", "Language of the output markdown doc.")
-	flag.StringVar(&output, "out", "./", "Output file name.")
+// convert a swagger doc
+func runConvert(args []string) error {
+	flagSet := flag.NewFlagSet("convert", flag.ExitOnError)
+	flagSet.StringVar(&localInput, "local", "./", "Local path of the input json or yaml.")
+	flagSet.StringVar(&webInput, "web", "", "Web url of the input json or yaml.")
+	flagSet.StringVar(&lang, "lang", "en", "Language of the output doc, a BCP-47 style tag like en, zh or zh-TW. "+
+		"Comma separated languages render one doc per language.")
+	flagSet.StringVar(&langDir, "lang-dir", "", "Directory of <tag>.json language packs to load.")
+	flagSet.StringVar(&langFile, "lang-file", "", "Language pack file used for the language given by -lang.")
+	flagSet.StringVar(&output, "out", "./", "Output file name.")
+	flagSet.StringVar(&format, "format", "md", "Format of the output doc, md, html, adoc, rst or confluence.")
+	flagSet.StringVar(&templateDir, "templates", "",
+		"Directory of text/template files overriding the built-in markdown layout per section.")
+	flagSet.BoolVar(&languageSwitcher, "lang-switcher", false,
+		"Link the docs of the other languages at the top of each doc when rendering several languages.")
+	flagSet.StringVar(&translations, "translations", "",
+		"Json catalog of spec text translations, like {\"zh\": {\"/info/description\": \"...\"}}.")
+	flagSet.StringVar(&layout, "layout", "single",
+		"Layout of the output, single file or split into a directory with an index page and a page per tag.")
+	flagSet.BoolVar(&splitComponents, "split-components", false,
+		"Write one file per component instead of a single components file in the split layout.")
+	flagSet.BoolVar(&watch, "watch", false,
+		"Poll the input and the files it references, regenerating the docs on change until interrupted.")
+	flagSet.DurationVar(&watchInterval, "watch-interval", DEFAULT_WATCH_INTERVAL, "Interval between two polls in watch mode.")
+	flagSet.IntVar(&jobs, "jobs", runtime.NumCPU(), "Number of specs converted in parallel in batch mode.")
+	flagSet.BoolVar(&merge, "merge", false, "Merge the inputs, given as <spec>[=/<path prefix>], into a single doc.")
+	flagSet.StringVar(&mergeTitle, "merge-title", "", "Title of the merged doc, the titles of the specs are joined by default.")
+	flagSet.StringVar(&includeTags, "include-tag", "", "Comma separated tags of the operations to document.")
+	flagSet.StringVar(&excludeTags, "exclude-tag", "", "Comma separated tags of the operations to leave out.")
+	flagSet.StringVar(&includePaths, "include-path", "",
+		"Comma separated path globs of the operations to document, * matches within a segment and ** across segments.")
+	flagSet.StringVar(&excludePaths, "exclude-path", "", "Comma separated path globs of the operations to leave out.")
+	flagSet.StringVar(&includeMethods, "include-method", "", "Comma separated http methods of the operations to document.")
+	flagSet.StringVar(&excludeMethods, "exclude-method", "", "Comma separated http methods of the operations to leave out.")
+	flagSet.StringVar(&includeOperationId, "include-operation-id", "", "Regexp of the operation ids to document.")
+	flagSet.StringVar(&excludeOperationId, "exclude-operation-id", "", "Regexp of the operation ids to leave out.")
+	flagSet.StringVar(&includeExtensions, "include-extension", "",
+		"Comma separated vendor extensions, as name[=value], of the operations to document.")
+	flagSet.StringVar(&excludeExtensions, "exclude-extension", "",
+		"Comma separated vendor extensions, as name[=value], of the operations to leave out, e.g. x-internal=true.")
+	flagSet.BoolVar(&excludeDeprecated, "exclude-deprecated", false, "Leave out the deprecated operations.")
+	flagSet.BoolVar(&deprecations, "deprecations", false,
+		"Add a section listing every deprecated operation, parameter and property.")
+	flagSet.BoolVar(&curlExamples, "curl", false,
+		"Add a curl command to every operation, the same as -code-samples curl.")
+	flagSet.StringVar(&codeSamples, "code-samples", "", "Comma separated languages of the request samples added "+
+		"to every operation, curl, go, python or javascript. Authentication is left as placeholders.")
+	flagSet.BoolVar(&classDiagram, "class-diagram", false,
+		"Add a components overview with a mermaid class diagram of the components and their references.")
+	flagSet.IntVar(&diagramLimit, "diagram-limit", DEFAULT_DIAGRAM_LIMIT,
+		"Number of components above which the class diagram is split into a diagram per tag.")
+	flagSet.Parse(args)
+
+	// several inputs, a directory or a glob convert a batch of specs into the output directory, the default -local
+	// is a single spec like it always was
+	inputs := flagSet.Args()
+	explicitInputs := len(inputs) > 0
+	flagSet.Visit(func(given *flag.Flag) {
+		explicitInputs = explicitInputs || given.Name == "local"
+	})
+	if len(inputs) == 0 {
+		inputs = []string{localInput}
+	}
+	if webInput != "" {
+		inputs = []string{webInput}
+	}
+
+	langTypes := make([]LanguageType, 0)
+	for _, name := range strings.Split(lang, ",") {
+		langType, err := ParseLanguage(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		langTypes = append(langTypes, langType)
+	}
+	if err := LoadLanguagePacks(langDir, langFile, langTypes[0]); err != nil {
+		return err
+	}
+	outputFormat, err := ParseOutputFormat(format)
+	if err != nil {
+		return err
+	}
 
-	flag.Parse()
+	outputLayout, err := ParseOutputLayout(layout)
+	if err != nil {
+		return err
+	}
 
-	fin, err := os.Open(localInput)
+	// an operation is documented when it matches every include criterion and no exclude criterion
+	include, err := ParseFilterCriteria(includeTags, includePaths, includeMethods, includeOperationId, false,
+		includeExtensions)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	exclude, err := ParseFilterCriteria(excludeTags, excludePaths, excludeMethods, excludeOperationId,
+		excludeDeprecated, excludeExtensions)
+	if err != nil {
+		return err
+	}
+	filter := NewOperationFilter(include, exclude)
+	sampleLanguages, err := ParseSampleLanguages(codeSamples)
+	if err != nil {
+		return err
+	}
+
+	newTransformer := func(input string, output string) *Transformer {
+		transformer := NewTransformer(input, output, DetectContentSource(input), langTypes[0], outputFormat)
+		transformer.Layout = outputLayout
+		transformer.SplitComponents = splitComponents
+		transformer.TemplateDir = templateDir
+		transformer.TranslationCatalog = translations
+		transformer.Filter = filter
+		transformer.DeprecationReport = deprecations
+		transformer.CodeSamples = sampleLanguages
+		transformer.CurlExamples = curlExamples
+		transformer.ComponentDiagram = classDiagram
+		transformer.DiagramLimit = diagramLimit
+		if len(langTypes) > 1 {
+			transformer.Languages = langTypes
+			transformer.LanguageSwitcher = languageSwitcher
+		}
+		return transformer
+	}
+	batchMode := !merge && explicitInputs && (len(inputs) > 1 || IsBatchInput(inputs[0]))
+	if watch && (merge || batchMode) {
+		return errors.New("watch mode converts a single spec")
+	}
+	if merge {
+		sources := make([]MergeSource, 0, len(inputs))
+		for _, input := range inputs {
+			sources = append(sources, ParseMergeSource(input))
+		}
+		transformer := newTransformer(sources[0].Input, output)
+		transformer.MergeSources = sources
+		transformer.MergeTitle = mergeTitle
+		return runTransformer(transformer)
+	}
+	if batchMode {
+		return runBatch(inputs, langTypes[0], outputFormat, newTransformer)
+	}
+
+	transformer := newTransformer(inputs[0], output)
+	if watch {
+		return watchTransformer(transformer)
+	}
+	return runTransformer(transformer)
+}
+
+// convert once, printing the output paths
+func runTransformer(transformer *Transformer) error {
+	if err := transformer.Run(); err != nil {
+		return err
+	}
+	for _, outputPath := range transformer.OutputPaths() {
+		fmt.Printf("%s\n", outputPath)
 	}
-	reader := bufio.NewReader(fin)
+	return nil
+}
+
+// regenerate the docs whenever the input changes, until interrupted
+func watchTransformer(transformer *Transformer) error {
+	watcher := NewWatcher(transformer)
+	watcher.Interval = watchInterval
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+	return watcher.Watch(stop)
+}
 
-	model := Model{}
-	err = json.NewDecoder(reader).Decode(&model)
+// convert a batch of specs in parallel, then write an index linking the docs
+func runBatch(inputs []string, langType LanguageType, format OutputFormat,
+	newTransformer func(string, string) *Transformer) error {
+	expanded, err := ExpandInputs(inputs)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	batch := &Batch{Inputs: expanded, OutputDir: output, Format: format, Jobs: jobs, NewTransformer: newTransformer}
+	batch.OnResult = func(result BatchResult) {
+		if result.Err != nil {
+			log.Printf("FAIL %s: %v", result.Input, result.Err)
+		} else {
+			log.Printf("ok   %s -> %s", result.Input, result.Output)
+		}
+	}
+	results := batch.Run()
 
-	fmt.Printf("%v", model.Info.Title)
+	terms, err := defaultLanguageRegistry.Resolve(langType)
+	if err != nil {
+		return err
+	}
+	indexPath, err := batch.WriteIndex(terms, results)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", indexPath)
 
-	//markdownWriter := &MdGenerator{FileName: output}
-	//markdownWriter.WriteHeader(model.Info.Title, H2)
-	//markdownWriter.Close()
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d specs failed", failed, len(results))
+	}
+	return nil
 }
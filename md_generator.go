@@ -23,7 +23,7 @@ const (
 )
 
 type MdGenerator struct {
-
+	NoContent string // content of the table cells without value
 }
 
 type TableLine struct {
@@ -31,10 +31,14 @@ type TableLine struct {
 }
 
 func (line *TableLine) Get(header string) string {
+	return line.GetOrDefault(header, NO_CONTENT)
+}
+
+func (line *TableLine) GetOrDefault(header string, defaultContent string) string {
 	if content, ok := line.Content[header]; ok {
 		return content
 	} else {
-		return NO_CONTENT
+		return defaultContent
 	}
 }
 
@@ -65,13 +69,32 @@ func (generator *MdGenerator) GetSingleLineCode(content string, level IndentLeve
 
 // generate multiple lines of code in markdown
 func (generator *MdGenerator) GetMultiLineCode(content string, level IndentLevel) string {
+	return generator.GetSourceCode(content, "", level)
+}
+
+// generate multiple lines of code in a given language in markdown
+func (generator *MdGenerator) GetSourceCode(content string, language string, level IndentLevel) string {
 	indent := strings.Repeat(" ", int(level) * 4)
 	lines := strings.Split(content, "\n")
 	finalCode := ""
 	for _, line := range lines {
 		finalCode += fmt.Sprintf("%s%s\n", indent, line)
 	}
-	return fmt.Sprintf("%s```\n%s\n%s```", indent, finalCode, indent)
+	return fmt.Sprintf("%s```%s\n%s\n%s```", indent, language, finalCode, indent)
+}
+
+// generate a paragraph in markdown, every non empty line of which is indented
+func (generator *MdGenerator) GetParagraph(content string, level IndentLevel) string {
+	indent := strings.Repeat(" ", int(level) * 4)
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	for index, line := range lines {
+		if len(strings.TrimSpace(line)) > 0 {
+			lines[index] = indent + line
+		} else {
+			lines[index] = ""
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (generator *MdGenerator) GetBoldLine(content string) string {
@@ -82,6 +105,16 @@ func (generator *MdGenerator) GetItalicLine(content string) string {
 	return fmt.Sprintf("*%s*", content)
 }
 
+// generate a link in markdown
+func (generator *MdGenerator) GetLink(content string, href string) string {
+	return fmt.Sprintf("[%s](%s)", content, href)
+}
+
+// generate an html anchor, which markdown links can point to
+func (generator *MdGenerator) GetAnchor(id string) string {
+	return fmt.Sprintf("<a id=\"%s\"></a>", id)
+}
+
 func (generator *MdGenerator) getTableLine(header []string, terms []string) TableLine {
 	if len(header) != len(terms) {
 		return TableLine{}
@@ -96,11 +129,17 @@ func (generator *MdGenerator) getTableLine(header []string, terms []string) Tabl
 }
 
 func (generator *MdGenerator) GetTable(header []string, lines []TableLine, level IndentLevel) string {
+	return generator.GetLabeledTable(header, header, lines, level)
+}
+
+// generate a table whose columns are looked up by header keys but displayed with labels
+func (generator *MdGenerator) GetLabeledTable(header []string, labels []string, lines []TableLine,
+	level IndentLevel) string {
 	indent := strings.Repeat(" ", int(level) * 4)
 	headerLine := indent
 	headerSepLine := indent
-	for _, colHeader := range header {
-		headerLine += fmt.Sprintf("|%s", colHeader)
+	for _, label := range labels {
+		headerLine += fmt.Sprintf("|%s", label)
 		headerSepLine += "|---"
 	}
 	headerSepLine += "|"
@@ -110,7 +149,7 @@ func (generator *MdGenerator) GetTable(header []string, lines []TableLine, level
 	for _, line := range lines {
 		currentLine := indent
 		for _, colHeader := range header {
-			currentLine += fmt.Sprintf("|%s", line.Get(colHeader))
+			currentLine += fmt.Sprintf("|%s", line.GetOrDefault(colHeader, generator.NoContent))
 		}
 		currentLine += "|\n"
 		lineContents += currentLine
@@ -121,5 +160,5 @@ func (generator *MdGenerator) GetTable(header []string, lines []TableLine, level
 
 // factory for MdGenerator
 func NewMdGenerator() *MdGenerator {
-	return &MdGenerator{}
+	return &MdGenerator{NoContent: NO_CONTENT}
 }
\ No newline at end of file
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+const (
+	// extension noting the spec an operation of a merged spec comes from
+	ORIGIN_EXTENSION = "x-origin"
+
+	componentRefPrefix = "#/components/"
+)
+
+// http methods of a path item, the other keys of a path item are not operations
+var operationMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true,
+}
+
+// MergeSource struct, a spec merged under a path prefix
+type MergeSource struct {
+	Input  string
+	Prefix string // prepended to every path of the spec, e.g. /pets
+	Name   string // namespace of the colliding components of the spec
+}
+
+// parse a merge source given as <spec>[=/<prefix>], the name is the last segment of the prefix or the file name.
+// Only a suffix starting with a slash is a prefix, so urls with query parameters are kept whole
+func ParseMergeSource(arg string) MergeSource {
+	source := MergeSource{Input: arg}
+	if index := strings.LastIndex(arg, "="); index > 0 && strings.HasPrefix(arg[index+1:], "/") {
+		source.Input, source.Prefix = arg[:index], normalizePrefix(arg[index+1:])
+	}
+	if segment := source.Prefix[strings.LastIndex(source.Prefix, "/")+1:]; segment != "" {
+		source.Name = segment
+	} else {
+		base := filepath.Base(inputPath(source.Input))
+		source.Name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return source
+}
+
+// prefix with a leading slash and without a trailing one, empty for the root
+func normalizePrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
+
+// SpecMerger struct, combining several specs into a single one
+type SpecMerger struct {
+	Title string // title of the merged spec, the titles of the sources are joined when empty
+}
+
+// merge the json contents of the sources, in the order of the sources
+func (merger *SpecMerger) Merge(sources []MergeSource, contents []string) (string, error) {
+	if len(sources) == 0 || len(sources) != len(contents) {
+		return "", errors.New("no spec to merge")
+	}
+	// the namespace tells apart the colliding components of the sources
+	inputs := make(map[string]string)
+	for _, source := range sources {
+		if input, ok := inputs[source.Name]; ok {
+			return "", fmt.Errorf("%s and %s have the same namespace %s, merge them under different prefixes",
+				input, source.Input, source.Name)
+		}
+		inputs[source.Name] = source.Input
+	}
+	specs := make([]map[string]interface{}, 0, len(contents))
+	for index, content := range contents {
+		spec := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(content), &spec); err != nil {
+			return "", fmt.Errorf("%s: %v", sources[index].Input, err)
+		}
+		specs = append(specs, spec)
+	}
+
+	renames, err := merger.componentRenames(sources, specs)
+	if err != nil {
+		return "", err
+	}
+	merged := map[string]interface{}{"openapi": specs[0]["openapi"], "info": merger.mergeInfo(sources, specs)}
+	components := make(map[string]interface{})
+	paths := make(map[string]interface{})
+	pathOrigins := make(map[string]string)
+	tags := make([]interface{}, 0)
+	tagIndexes := make(map[string]int)
+	servers := make([]interface{}, 0)
+	serverUrls := make(map[string]bool)
+
+	for index, spec := range specs {
+		source := sources[index]
+		renameRefs(spec, renames[index])
+
+		specComponents, _ := spec["components"].(map[string]interface{})
+		for _, section := range sortedObjectKeys(specComponents) {
+			entries, ok := specComponents[section].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			mergedEntries, ok := components[section].(map[string]interface{})
+			if !ok {
+				mergedEntries = make(map[string]interface{})
+				components[section] = mergedEntries
+			}
+			for name, entry := range entries {
+				if renamed, ok := renames[index][componentRefPrefix+section+"/"+name]; ok {
+					name = strings.TrimPrefix(renamed, componentRefPrefix+section+"/")
+				}
+				mergedEntries[name] = entry
+			}
+		}
+
+		specPaths, _ := spec["paths"].(map[string]interface{})
+		for _, apiPath := range sortedObjectKeys(specPaths) {
+			mergedPath := source.Prefix + apiPath
+			if apiPath == "/" && source.Prefix != "" {
+				mergedPath = source.Prefix
+			}
+			if origin, ok := pathOrigins[mergedPath]; ok {
+				return "", fmt.Errorf("path %s is defined by both %s and %s", mergedPath, origin, source.Input)
+			}
+			pathOrigins[mergedPath] = source.Input
+			if methods, ok := specPaths[apiPath].(map[string]interface{}); ok {
+				for method, operation := range methods {
+					if operation, ok := operation.(map[string]interface{}); ok && operationMethods[method] {
+						operation[ORIGIN_EXTENSION] = specTitle(source, spec)
+					}
+				}
+			}
+			paths[mergedPath] = specPaths[apiPath]
+		}
+
+		// tags declared by several specs are unified, the first description wins
+		specTags, _ := spec["tags"].([]interface{})
+		for _, tag := range specTags {
+			tagObject, ok := tag.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := tagObject["name"].(string)
+			if existing, ok := tagIndexes[name]; ok {
+				existingTag := tags[existing].(map[string]interface{})
+				if description, _ := existingTag["description"].(string); description == "" {
+					existingTag["description"] = tagObject["description"]
+				}
+				continue
+			}
+			tagIndexes[name] = len(tags)
+			tags = append(tags, tagObject)
+		}
+
+		specServers, _ := spec["servers"].([]interface{})
+		for _, server := range specServers {
+			serverObject, ok := server.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			url, _ := serverObject["url"].(string)
+			if !serverUrls[url] {
+				serverUrls[url] = true
+				servers = append(servers, serverObject)
+			}
+		}
+	}
+
+	merged["paths"] = paths
+	merged["components"] = components
+	if len(tags) > 0 {
+		merged["tags"] = tags
+	}
+	if len(servers) > 0 {
+		merged["servers"] = servers
+	}
+	content, err := json.MarshalIndent(merged, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// info of the merged spec, the description lists the merged specs
+func (merger *SpecMerger) mergeInfo(sources []MergeSource, specs []map[string]interface{}) map[string]interface{} {
+	first, _ := specs[0]["info"].(map[string]interface{})
+	info := make(map[string]interface{})
+	for _, key := range []string{"version", "contact", "license"} {
+		if value, ok := first[key]; ok {
+			info[key] = value
+		}
+	}
+
+	titles := make([]string, 0, len(specs))
+	summaries := make([]string, 0, len(specs))
+	for index, spec := range specs {
+		title := specTitle(sources[index], spec)
+		titles = append(titles, title)
+		summary := title
+		if specInfo, ok := spec["info"].(map[string]interface{}); ok && specInfo["version"] != nil {
+			summary += fmt.Sprintf(" %v", specInfo["version"])
+		}
+		if sources[index].Prefix != "" {
+			summary += fmt.Sprintf(" (%s)", sources[index].Prefix)
+		}
+		summaries = append(summaries, summary)
+	}
+	info["title"] = merger.Title
+	if merger.Title == "" {
+		info["title"] = strings.Join(titles, ", ")
+	}
+	info["description"] = strings.Join(summaries, ", ")
+	return info
+}
+
+// renames of the components per spec, components defined differently by several specs are namespaced
+// with the name of their source, e.g. Pet becomes pets.Pet
+func (merger *SpecMerger) componentRenames(sources []MergeSource, specs []map[string]interface{}) ([]map[string]string, error) {
+	definitions := make(map[string][]int)
+	refs := make([]string, 0)
+	for index, spec := range specs {
+		components, _ := spec["components"].(map[string]interface{})
+		for section, entries := range components {
+			entries, _ := entries.(map[string]interface{})
+			for name := range entries {
+				ref := componentRefPrefix + section + "/" + name
+				if _, ok := definitions[ref]; !ok {
+					refs = append(refs, ref)
+				}
+				definitions[ref] = append(definitions[ref], index)
+			}
+		}
+	}
+
+	renames := make([]map[string]string, len(specs))
+	for index := range renames {
+		renames[index] = make(map[string]string)
+	}
+	for _, ref := range refs {
+		indexes := definitions[ref]
+		section, name := splitComponentRef(ref)
+		first := specs[indexes[0]]["components"].(map[string]interface{})[section].(map[string]interface{})[name]
+		collides := false
+		for _, index := range indexes[1:] {
+			entry := specs[index]["components"].(map[string]interface{})[section].(map[string]interface{})[name]
+			if !reflect.DeepEqual(first, entry) {
+				collides = true
+			}
+		}
+		if !collides {
+			continue
+		}
+		for _, index := range indexes {
+			renamed := componentRefPrefix + section + "/" + sources[index].Name + "." + name
+			if _, ok := definitions[renamed]; ok {
+				return nil, fmt.Errorf("component %s of %s collides with an existing component",
+					strings.TrimPrefix(renamed, componentRefPrefix), sources[index].Input)
+			}
+			renames[index][ref] = renamed
+		}
+	}
+	return renames, nil
+}
+
+// section and name of a component reference, e.g. #/components/schemas/Pet
+func splitComponentRef(ref string) (string, string) {
+	parts := strings.SplitN(strings.TrimPrefix(ref, componentRefPrefix), "/", 2)
+	return parts[0], parts[1]
+}
+
+// rewrite the component references of a json value in place
+func renameRefs(value interface{}, renames map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				if renamed, ok := renames[ref]; ok {
+					value[key] = renamed
+				}
+				continue
+			}
+			renameRefs(child, renames)
+		}
+	case []interface{}:
+		for _, child := range value {
+			renameRefs(child, renames)
+		}
+	}
+}
+
+// title of a merged spec, its name when it has none
+func specTitle(source MergeSource, spec map[string]interface{}) string {
+	if info, ok := spec["info"].(map[string]interface{}); ok {
+		if title, ok := info["title"].(string); ok && title != "" {
+			return title
+		}
+	}
+	return source.Name
+}
+
+// MergeContentGetter struct, retrieving the merged content of several local or web specs
+type MergeContentGetter struct {
+	merger  *SpecMerger
+	sources []MergeSource
+}
+
+// read every source, then merge them
+func (mcg *MergeContentGetter) GetContent() (string, error) {
+	contents := make([]string, 0, len(mcg.sources))
+	for _, source := range mcg.sources {
+		content, err := NewSwaggerContentGetter(source.Input, DetectContentSource(source.Input)).GetContent()
+		if err != nil {
+			return "", err
+		}
+		contents = append(contents, content)
+	}
+	return mcg.merger.Merge(mcg.sources, contents)
+}
+
+// sources are read from wherever they are, local or web
+func (mcg *MergeContentGetter) GetLocalContent() (string, error) {
+	return mcg.GetContent()
+}
+
+// sources are read from wherever they are, local or web
+func (mcg *MergeContentGetter) GetWebContent() (string, error) {
+	return mcg.GetContent()
+}
+
+// factory for SpecMerger
+func NewSpecMerger(title string) *SpecMerger {
+	return &SpecMerger{Title: title}
+}
+
+// factory for MergeContentGetter
+func NewMergeContentGetter(merger *SpecMerger, sources []MergeSource) *MergeContentGetter {
+	return &MergeContentGetter{merger: merger, sources: sources}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// test ParseMergeSource
+func TestParseMergeSource(t *testing.T) {
+	t.Log("Test merge - ParseMergeSource with and without a prefix")
+	{
+		source := ParseMergeSource("specs/pets.json=/pets/v1/")
+		if source.Input != "specs/pets.json" || source.Prefix != "/pets/v1" || source.Name != "v1" {
+			t.Errorf("unexpected source %+v", source)
+		}
+		source = ParseMergeSource("specs/store.json")
+		if source.Input != "specs/store.json" || source.Prefix != "" || source.Name != "store" {
+			t.Errorf("unexpected source %+v", source)
+		}
+	}
+
+	t.Log("Test merge - ParseMergeSource with a query string")
+	{
+		source := ParseMergeSource("https://host/spec.json?v=2")
+		if source.Input != "https://host/spec.json?v=2" || source.Prefix != "" || source.Name != "spec" {
+			t.Errorf("unexpected source %+v", source)
+		}
+		source = ParseMergeSource("https://host/spec.json?v=2=/pets")
+		if source.Input != "https://host/spec.json?v=2" || source.Prefix != "/pets" || source.Name != "pets" {
+			t.Errorf("unexpected source %+v", source)
+		}
+	}
+}
+
+// test merging specs through the transformer
+func TestSpecMerger_Merge(t *testing.T) {
+	petstore := readTestSpec(t)
+	store := strings.Replace(strings.Replace(petstore, "Swagger Petstore", "Store API", 1),
+		`"name": {`, `"nickname": {`, 1)
+
+	t.Log("Test merge - prefix paths, namespace colliding components and note the origin")
+	{
+		dir := t.TempDir()
+		for name, content := range map[string]string{"pets.json": petstore, "store.json": store} {
+			if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		transformer := NewTransformer("", filepath.Join(dir, "api.md"), LOCAL_SOURCE, ENGLISH, MARKDOWN_FORMAT)
+		transformer.MergeSources = []MergeSource{ParseMergeSource(filepath.Join(dir, "pets.json") + "=/pets"),
+			ParseMergeSource(filepath.Join(dir, "store.json") + "=/store")}
+		transformer.MergeTitle = "Gateway"
+		if err := transformer.Run(); err != nil {
+			t.Fatal(err)
+		}
+
+		doc := transformer.Doc
+		if doc.Model.Info.Title != "Gateway" || len(doc.Apis) != 8 || len(doc.Model.Servers) != 1 {
+			t.Errorf("unexpected merged doc %s with %d apis", doc.Model.Info.Title, len(doc.Apis))
+		}
+		if doc.Apis[0].Path != "/pets/pets" || doc.Apis[0].Origin != "Swagger Petstore" {
+			t.Errorf("unexpected api %v from %s", doc.Apis[0], doc.Apis[0].Origin)
+		}
+		names := make([]string, 0)
+		for _, component := range doc.Components {
+			names = append(names, component.Name)
+		}
+		// identical components are kept once
+		if strings.Join(names, ",") != "Order,pets.Pet,store.Pet" {
+			t.Errorf("unexpected components %v", names)
+		}
+		if refs := doc.ApiComponentRefs(doc.Apis[len(doc.Apis)-1]); len(refs) == 0 || refs[0] == "Pet" {
+			t.Errorf("the refs of the store apis should be namespaced, got %v", refs)
+		}
+		if !strings.Contains(transformer.OutputContent, "    #### Origin\n    + Store API\n") {
+			t.Errorf("the origin is not rendered")
+		}
+	}
+
+	t.Log("Test merge - sources of the same namespace are rejected")
+	{
+		sources := []MergeSource{ParseMergeSource("v1/pets.json"), ParseMergeSource("v2/pets.json=/")}
+		if _, err := NewSpecMerger("").Merge(sources, []string{petstore, store}); err == nil ||
+			!strings.Contains(err.Error(), "same namespace pets") {
+			t.Errorf("expected a namespace collision, got %v", err)
+		}
+	}
+
+	t.Log("Test merge - paths colliding after prefixing are rejected")
+	{
+		sources := []MergeSource{ParseMergeSource("pets.json"), ParseMergeSource("store.json")}
+		if _, err := NewSpecMerger("").Merge(sources, []string{petstore, store}); err == nil ||
+			!strings.Contains(err.Error(), "path /pets is defined by both") {
+			t.Errorf("expected a path collision, got %v", err)
+		}
+	}
+}
@@ -1,5 +1,13 @@
 package main
 
+// SecurityScheme struct, how a request is authenticated
+type SecurityScheme struct {
+	Type string `json:"type"`   // apiKey, http, oauth2 or openIdConnect
+	Scheme string `json:"scheme"` // http authentication scheme, e.g. basic or bearer
+	In string `json:"in"`         // location of an api key, query, header or cookie
+	Name string `json:"name"`     // name of an api key
+}
+
 type Model struct {
 
 	OpenApi string `json:"openapi"`
@@ -12,24 +20,35 @@ type Model struct {
 		Contact map[string]string `json:"contact"`
 		License map[string]string `json:"license"`
 		Version string `json:"version"`
+		I18n map[string]map[string]string `json:"x-i18n"`
 	} `json:"info"`
 
 	Servers []struct {
 		Url string `json:"url"`
 		Description string `json:"description"`
+		Variables map[string]struct {
+			Default string `json:"default"`
+		} `json:"variables"`
 	} `json:"servers"`
 
+	// alternative security requirements of every operation, by security scheme name
+	Security []map[string][]string `json:"security"`
+
 	//Produces []string `json:"produces"`
 	//Consumes []string `json:"consumes"`
 	Tags []struct {
+		Index int `json:"-"` // position of the tag in the spec, which the translations refer to
 		Name string `json:"name"`
 		Description string `json:"description"`
+		I18n map[string]map[string]string `json:"x-i18n"`
 	} `json:"tags"`
 
 	Paths map[string]interface{} `json:"paths"`
 
 	Components struct{
 		Schemas map[string]interface{} `json:"schemas"`
+		Parameters map[string]interface{} `json:"parameters"`
+		SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
 	} `json:"components"`
 
 	//Responses map[string]interface{} `json:"responses"`
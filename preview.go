@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// interval of the keep-alive comments sent to the browsers listening for reloads
+const PREVIEW_KEEP_ALIVE = 15 * time.Second
+
+// script reloading the page when the preview server pushes an event
+const previewReloadScript = `<script>new EventSource("/events").onmessage = function () { location.reload(); };</script>`
+
+// PreviewServer struct, serving the html docs of a watched spec and pushing reloads to open browsers
+type PreviewServer struct {
+	Watcher *Watcher
+
+	mutex   sync.RWMutex
+	pages   map[string]string // rendered page per file name, e.g. api.html or api.zh.html
+	index   string            // file name of the page of the first language
+	failure error             // error of the last render
+	clients map[chan struct{}]bool
+}
+
+// routes of the server
+func (server *PreviewServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", server.handlePage)
+	mux.HandleFunc("/events", server.handleEvents)
+	return mux
+}
+
+// serve a rendered page, or the diagnostics of the last render when it failed
+func (server *PreviewServer) handlePage(writer http.ResponseWriter, request *http.Request) {
+	server.mutex.RLock()
+	defer server.mutex.RUnlock()
+
+	writer.Header().Set("Content-Type", HTML_FORMAT.ContentType())
+	writer.Header().Set("Cache-Control", "no-store")
+	if server.failure != nil {
+		fmt.Fprintf(writer, "<!DOCTYPE html>\n<html>\n<body>\n<pre>%s</pre>\n%s\n</body>\n</html>\n",
+			html.EscapeString(server.failure.Error()), previewReloadScript)
+		return
+	}
+	name := strings.TrimPrefix(request.URL.Path, "/")
+	if name == "" {
+		name = server.index
+	}
+	page, ok := server.pages[name]
+	if !ok {
+		http.NotFound(writer, request)
+		return
+	}
+	writer.Write([]byte(injectReloadScript(page)))
+}
+
+// push a reload event whenever the docs are rendered again
+func (server *PreviewServer) handleEvents(writer http.ResponseWriter, request *http.Request) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		http.Error(writer, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	reload := make(chan struct{}, 1)
+	server.mutex.Lock()
+	server.clients[reload] = true
+	server.mutex.Unlock()
+	defer func() {
+		server.mutex.Lock()
+		delete(server.clients, reload)
+		server.mutex.Unlock()
+	}()
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(PREVIEW_KEEP_ALIVE)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case <-reload:
+			fmt.Fprint(writer, "data: reload\n\n")
+		case <-keepAlive.C:
+			fmt.Fprint(writer, ": keep-alive\n\n")
+		}
+		flusher.Flush()
+	}
+}
+
+// keep the pages of a render, then notify every open browser
+func (server *PreviewServer) update(err error) {
+	transformer := server.Watcher.Transformer
+	server.mutex.Lock()
+	server.failure = err
+	if err == nil {
+		server.pages = make(map[string]string)
+		for index, lang := range transformer.languages() {
+			name := filepath.Base(transformer.OutputPathFor(lang))
+			server.pages[name] = transformer.OutputContents[lang]
+			if index == 0 {
+				server.index = name
+			}
+		}
+	} else {
+		log.Printf("%s: %v", transformer.Input, err)
+	}
+	for client := range server.clients {
+		select {
+		case client <- struct{}{}:
+		default:
+		}
+	}
+	server.mutex.Unlock()
+}
+
+// add the reload script at the end of the body of a page
+func injectReloadScript(page string) string {
+	if index := strings.LastIndex(page, "</body>"); index >= 0 {
+		return page[:index] + previewReloadScript + "\n" + page[index:]
+	}
+	return page + previewReloadScript + "\n"
+}
+
+// factory for PreviewServer, the transformer renders html pages named after the default output
+func NewPreviewServer(transformer *Transformer) *PreviewServer {
+	transformer.Format = HTML_FORMAT
+	transformer.Output = DEFAULT_OUTPUT_NAME + HTML_FORMAT.Extension()
+	server := &PreviewServer{Watcher: NewWatcher(transformer), pages: make(map[string]string),
+		clients: make(map[chan struct{}]bool)}
+	server.Watcher.DryRun = true
+	server.Watcher.OnRun = server.update
+	return server
+}
+
+// run the preview command until interrupted
+func runPreviewCommand(args []string) error {
+	flagSet := flag.NewFlagSet("preview", flag.ExitOnError)
+	addr := flagSet.String("addr", "127.0.0.1:8000", "Address to listen on.")
+	previewLang := flagSet.String("lang", "en", "Comma separated languages of the docs.")
+	previewTranslations := flagSet.String("translations", "", "Json catalog of spec text translations.")
+	interval := flagSet.Duration("watch-interval", DEFAULT_WATCH_INTERVAL, "Interval between two polls of the spec.")
+	flagSet.Parse(args)
+	if flagSet.NArg() != 1 {
+		return errors.New("usage: preview [-addr host:port] [-lang tags] [-translations file] <spec>")
+	}
+
+	langTypes := make([]LanguageType, 0)
+	for _, name := range strings.Split(*previewLang, ",") {
+		langType, err := ParseLanguage(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		if _, err := defaultLanguageRegistry.Resolve(langType); err != nil {
+			return err
+		}
+		langTypes = append(langTypes, langType)
+	}
+
+	transformer := NewTransformer(flagSet.Arg(0), "", LOCAL_SOURCE, langTypes[0], HTML_FORMAT)
+	transformer.TranslationCatalog = *previewTranslations
+	if len(langTypes) > 1 {
+		transformer.Languages = langTypes
+		transformer.LanguageSwitcher = true
+	}
+	previewServer := NewPreviewServer(transformer)
+	previewServer.Watcher.Interval = *interval
+	httpServer := &http.Server{Addr: *addr, Handler: previewServer.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	stop := make(chan struct{})
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- previewServer.Watcher.Watch(stop)
+	}()
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		select {
+		case <-signals:
+		case err := <-watchErr:
+			watchErr <- err
+		}
+		close(stop)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		httpServer.Shutdown(ctx)
+	}()
+
+	log.Printf("previewing %s on http://%s/", flagSet.Arg(0), *addr)
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	select {
+	case err := <-watchErr:
+		return err
+	default:
+		return nil
+	}
+}
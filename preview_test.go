@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// test PreviewServer serving the docs and pushing reloads
+func TestPreviewServer(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "petstore.json")
+	if err := ioutil.WriteFile(input, []byte(readTestSpec(t)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	transformer := NewTransformer(input, "", LOCAL_SOURCE, ENGLISH, MARKDOWN_FORMAT)
+	transformer.Languages = []LanguageType{ENGLISH, CHINESE}
+	previewServer := NewPreviewServer(transformer)
+	previewServer.Watcher.Interval, previewServer.Watcher.Debounce = 10*time.Millisecond, 10*time.Millisecond
+	stop := make(chan struct{})
+	defer close(stop)
+	go previewServer.Watcher.Watch(stop)
+	httpServer := httptest.NewServer(previewServer.Handler())
+	defer httpServer.Close()
+
+	get := func(path string) string {
+		// the first render runs in the background
+		for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); {
+			response, err := http.Get(httpServer.URL + path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := ioutil.ReadAll(response.Body)
+			response.Body.Close()
+			if response.StatusCode == http.StatusOK {
+				return string(body)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("%s is not served", path)
+		return ""
+	}
+
+	t.Log("Test preview server - serve the html docs with the reload script")
+	{
+		page := get("/")
+		if !strings.Contains(page, "<title>Swagger Petstore</title>") || !strings.Contains(page, previewReloadScript) {
+			t.Errorf("unexpected page:\n%s", page)
+		}
+		if !strings.Contains(get("/api.zh.html"), "概述") {
+			t.Errorf("the chinese page is not served")
+		}
+	}
+
+	t.Log("Test preview server - push a reload when the spec changes")
+	{
+		response, err := http.Get(httpServer.URL + "/events")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		if response.Header.Get("Content-Type") != "text/event-stream" {
+			t.Errorf("unexpected content type %s", response.Header.Get("Content-Type"))
+		}
+
+		spec := strings.Replace(readTestSpec(t), "Swagger Petstore", "Preview Petstore", 1)
+		if err := ioutil.WriteFile(input, []byte(spec), 0644); err != nil {
+			t.Fatal(err)
+		}
+		events := make(chan string, 1)
+		go func() {
+			line, _ := bufio.NewReader(response.Body).ReadString('\n')
+			events <- line
+		}()
+		select {
+		case event := <-events:
+			if event != "data: reload\n" {
+				t.Errorf("unexpected event %q", event)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("no reload was pushed")
+		}
+		if !strings.Contains(get("/"), "<title>Preview Petstore</title>") {
+			t.Errorf("the page is not rendered again")
+		}
+	}
+}
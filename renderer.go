@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type OutputFormat int
+
+const (
+	MARKDOWN_FORMAT   OutputFormat = 0
+	HTML_FORMAT       OutputFormat = 1
+	ASCIIDOC_FORMAT   OutputFormat = 2
+	RST_FORMAT        OutputFormat = 3
+	CONFLUENCE_FORMAT OutputFormat = 4
+)
+
+// Invalid output format, no renderer is able to produce it
+var InvalidOutputFormat = errors.New("invalid output format")
+
+// An interface rendering an extracted swagger doc into a specific output format
+type Renderer interface {
+	Render(doc *Document) (string, error)
+}
+
+var anchorInvalidChars = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// parse the name of an output format given on the command line
+func ParseOutputFormat(name string) (OutputFormat, error) {
+	switch strings.ToLower(name) {
+	case "md", "markdown":
+		return MARKDOWN_FORMAT, nil
+	case "html":
+		return HTML_FORMAT, nil
+	case "adoc", "asciidoc":
+		return ASCIIDOC_FORMAT, nil
+	case "rst":
+		return RST_FORMAT, nil
+	case "confluence":
+		return CONFLUENCE_FORMAT, nil
+	default:
+		return MARKDOWN_FORMAT, InvalidOutputFormat
+	}
+}
+
+// file extension of the documents in an output format
+func (format OutputFormat) Extension() string {
+	switch format {
+	case HTML_FORMAT:
+		return ".html"
+	case ASCIIDOC_FORMAT:
+		return ".adoc"
+	case RST_FORMAT:
+		return ".rst"
+	case CONFLUENCE_FORMAT:
+		return ".xhtml"
+	default:
+		return ".md"
+	}
+}
+
+// media type of the documents in an output format
+func (format OutputFormat) ContentType() string {
+	switch format {
+	case HTML_FORMAT:
+		return "text/html; charset=utf-8"
+	case ASCIIDOC_FORMAT:
+		return "text/asciidoc; charset=utf-8"
+	case RST_FORMAT:
+		return "text/x-rst; charset=utf-8"
+	case CONFLUENCE_FORMAT:
+		return "application/xhtml+xml; charset=utf-8"
+	default:
+		return "text/markdown; charset=utf-8"
+	}
+}
+
+// generate an anchor id which is stable for the same input
+func GetAnchor(prefix string, parts ...string) string {
+	anchor := strings.ToLower(strings.Join(parts, "-"))
+	anchor = strings.Trim(anchorInvalidChars.ReplaceAllString(anchor, "-"), "-")
+	return prefix + "-" + anchor
+}
+
+// anchor id of an API
+func GetApiAnchor(api Api) string {
+	return GetAnchor("op", api.Method, api.Path)
+}
+
+// anchor id of a component
+func GetComponentAnchor(componentName string) string {
+	return GetAnchor("component", componentName)
+}
+
+// LanguageLink struct, a link to the document of a language
+type LanguageLink struct {
+	Lang    LanguageType
+	Href    string
+	Current bool
+}
+
+// add links to the documents of other languages at the top of a rendered document
+func AddLanguageSwitcher(format OutputFormat, content string, links []LanguageLink) string {
+	items := make([]string, 0, len(links))
+	for _, link := range links {
+		switch {
+		case link.Current:
+			items = append(items, string(link.Lang))
+		case format == HTML_FORMAT || format == CONFLUENCE_FORMAT:
+			items = append(items, fmt.Sprintf("<a href=\"%s\" hreflang=\"%s\">%s</a>",
+				html.EscapeString(link.Href), link.Lang, link.Lang))
+		case format == ASCIIDOC_FORMAT:
+			items = append(items, fmt.Sprintf("link:%s[%s]", link.Href, link.Lang))
+		case format == RST_FORMAT:
+			items = append(items, fmt.Sprintf("`%s <%s>`__", link.Lang, link.Href))
+		default:
+			items = append(items, fmt.Sprintf("[%s](%s)", link.Lang, link.Href))
+		}
+	}
+	switcher := strings.Join(items, " | ")
+
+	switch format {
+	case HTML_FORMAT:
+		return strings.Replace(content, "<main>\n",
+			fmt.Sprintf("<main>\n<nav class=\"languages\">%s</nav>\n", switcher), 1)
+	case CONFLUENCE_FORMAT:
+		return fmt.Sprintf("<p>%s</p>\n%s", switcher, content)
+	case ASCIIDOC_FORMAT, RST_FORMAT:
+		// after the document header or title, which ends with the first empty line
+		return strings.Replace(content, "\n\n", fmt.Sprintf("\n\n%s\n\n", switcher), 1)
+	default:
+		return fmt.Sprintf("%s\n\n%s", switcher, content)
+	}
+}
+
+// keys of a string map in sorted order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// factory for renderers, markdown is rendered by the analyzer itself
+func NewRenderer(format OutputFormat, analyzer *SwaggerAnalyzer) (Renderer, error) {
+	switch format {
+	case MARKDOWN_FORMAT:
+		return analyzer, nil
+	case HTML_FORMAT:
+		return NewHtmlRenderer(analyzer.terms), nil
+	case ASCIIDOC_FORMAT:
+		return NewAsciiDocRenderer(analyzer.terms), nil
+	case RST_FORMAT:
+		return NewRstRenderer(analyzer.terms), nil
+	case CONFLUENCE_FORMAT:
+		return NewConfluenceRenderer(analyzer.terms), nil
+	default:
+		return nil, InvalidOutputFormat
+	}
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// heading underline characters, from the document title down to operation details
+var rstHeadingChars = []string{"#", "=", "-", "~", "^"}
+
+var rstInlineEscaper = strings.NewReplacer("\\", "\\\\", "*", "\\*", "`", "\\`", "|", "\\|", "_", "\\_")
+
+type RstRenderer struct {
+	terms map[string]string // terms associated with language settings
+}
+
+// render an extracted doc as a reStructuredText document
+func (renderer *RstRenderer) Render(doc *Document) (string, error) {
+	title := renderer.escape(doc.Model.Info.Title)
+	overline := strings.Repeat(rstHeadingChars[0], displayWidth(title))
+	content := fmt.Sprintf("%s\n%s\n%s\n\n", overline, title, overline)
+	content += ".. contents::\n   :local:\n   :depth: 2\n\n"
+	content += renderer.FormatOverview(doc.Model)
+	if len(doc.ComponentDiagrams) > 0 {
+		content += renderer.FormatComponentDiagrams(doc.ComponentDiagrams)
+	}
+	content += renderer.FormatComponents(doc)
+	content += renderer.FormatPaths(doc)
+	if len(doc.Deprecations) > 0 {
+		content += renderer.FormatDeprecations(doc.Deprecations)
+	}
+	return content, nil
+}
+
+// format the overview section, including info, servers and tags
+func (renderer *RstRenderer) FormatOverview(swaggerModel *Model) string {
+	overview := renderer.GetHeader(renderer.terms["overview"], 1)
+	overview += fmt.Sprintf("**%s**\n\n", renderer.escape(swaggerModel.Info.Description))
+
+	overview += renderer.GetHeader(renderer.terms["contact"], 2)
+	for _, key := range sortedKeys(swaggerModel.Info.Contact) {
+		overview += fmt.Sprintf("* %s : %s\n", key, renderer.escape(swaggerModel.Info.Contact[key]))
+	}
+	overview += "\n"
+
+	overview += renderer.GetHeader(renderer.terms["license"], 2)
+	for _, key := range sortedKeys(swaggerModel.Info.License) {
+		overview += fmt.Sprintf("* %s : %s\n", key, renderer.escape(swaggerModel.Info.License[key]))
+	}
+	overview += "\n"
+
+	overview += renderer.GetHeader(renderer.terms["version"], 2)
+	overview += fmt.Sprintf("%s\n\n", renderer.escape(swaggerModel.Info.Version))
+
+	overview += renderer.GetHeader(renderer.terms["servers"], 2)
+	for index, server := range swaggerModel.Servers {
+		overview += fmt.Sprintf("* %s\n\n  * %s : ``%s``\n  * %s : %s\n\n",
+			renderer.escape(LocalizeServerName(renderer.terms, index)), renderer.escape(renderer.terms["url"]),
+			server.Url, renderer.escape(renderer.terms["description"]), renderer.escape(server.Description))
+	}
+
+	overview += renderer.GetHeader(renderer.terms["tags"], 2)
+	for _, tag := range swaggerModel.Tags {
+		overview += fmt.Sprintf("%s\n    %s\n\n", renderer.escape(tag.Name), renderer.escape(tag.Description))
+	}
+	return overview
+}
+
+// format the components section
+func (renderer *RstRenderer) FormatComponents(doc *Document) string {
+	content := renderer.GetHeader(renderer.terms["components"], 1)
+	for _, component := range doc.Components {
+		content += renderer.FormatComponent(doc, &component)
+	}
+	return content
+}
+
+// format a single component, labelled so it can be referenced
+func (renderer *RstRenderer) FormatComponent(doc *Document, component *Component) string {
+	content := renderer.GetTarget(doc.ComponentAnchor(component.Name))
+	content += renderer.GetHeader(renderer.escape(component.Name), 2)
+	if component.Description != "" {
+		content += renderer.escape(strings.TrimSpace(component.Description)) + "\n\n"
+	}
+	content += fmt.Sprintf("%s : ``%s``\n\n", renderer.escape(renderer.terms["type"]), component.Type)
+
+	rows := make([][]string, 0, len(component.Properties))
+	for _, property := range component.Properties {
+		rows = append(rows, []string{renderer.FormatDeprecated(renderer.escape(property.Name), property.Deprecation),
+			fmt.Sprintf("*%s*", renderer.escape(property.Type)),
+			renderer.escape(LocalizeBool(renderer.terms, property.Required)), renderer.escape(property.Description),
+			renderer.escape(property.Example)})
+	}
+	content += renderer.GetListTable(renderer.escape(renderer.terms["properties"]), componentTableHeader, rows)
+
+	content += fmt.Sprintf("%s\n\n", renderer.escape(renderer.terms["json_representation"]))
+	content += renderer.GetCodeBlock("json", component.Code)
+
+	if refs := doc.ComponentRefs(*component); len(refs) > 0 {
+		content += renderer.terms["references"] + ": " + renderer.formatComponentRefs(doc, refs) + "\n\n"
+	}
+	if usages := doc.ComponentUsages(component.Name); len(usages) > 0 {
+		refs := make([]string, 0, len(usages))
+		for _, api := range usages {
+			refs = append(refs, renderer.formatApiRef(doc, api))
+		}
+		content += renderer.terms["used_by"] + ": " + strings.Join(refs, ", ") + "\n\n"
+	}
+	return content
+}
+
+// format the paths section
+func (renderer *RstRenderer) FormatPaths(doc *Document) string {
+	content := renderer.GetHeader(renderer.terms["paths"], 1)
+	for index, api := range doc.Apis {
+		content += renderer.FormatAPI(doc, index+1, api)
+	}
+	return content
+}
+
+// format an API, labelled so it can be referenced
+func (renderer *RstRenderer) FormatAPI(doc *Document, apiIndex int, api Api) string {
+	content := renderer.GetTarget(doc.ApiAnchor(api))
+	content += renderer.GetHeader(fmt.Sprintf("%d. %s", apiIndex,
+		renderer.FormatDeprecated(renderer.escape(api.Title()), api.Deprecation)), 2)
+	if api.Description != "" {
+		content += renderer.escape(strings.TrimSpace(api.Description)) + "\n\n"
+	}
+	content += fmt.Sprintf("%s : ``%s``\n\n", renderer.escape(renderer.terms["operation_id"]), api.OperationId)
+	content += renderer.GetCodeBlock("http", fmt.Sprintf("%s %s", strings.ToUpper(api.Method), api.Path))
+
+	if len(api.Parameters) > 0 {
+		rows := make([][]string, 0, len(api.Parameters))
+		for _, parameter := range api.Parameters {
+			rows = append(rows, []string{renderer.escape(parameter.In),
+				renderer.FormatDeprecated(renderer.escape(parameter.Name), parameter.Deprecation),
+				renderer.escape(parameter.Description), renderer.escape(parameter.Type)})
+		}
+		content += renderer.GetHeader(renderer.terms["parameters"], 3)
+		content += renderer.GetListTable("", parameterTableHeader, rows)
+	}
+
+	if len(api.Responses) > 0 {
+		rows := make([][]string, 0, len(api.Responses))
+		for _, response := range api.Responses {
+			rows = append(rows, []string{renderer.escape(response.StatusCode),
+				renderer.escape(response.Description),
+				renderer.escape(LocalizeSchema(renderer.terms, response.Schema))})
+		}
+		content += renderer.GetHeader(renderer.terms["responses"], 3)
+		content += renderer.GetListTable("", responseTableHeader, rows)
+	}
+
+	if len(api.CodeSamples) > 0 {
+		content += renderer.GetHeader(renderer.terms["code_samples"], 3)
+		for _, sample := range api.CodeSamples {
+			content += renderer.escape(sample.Label) + "\n\n"
+			content += renderer.GetCodeBlock(sample.Lang, sample.Source)
+		}
+	}
+
+	if refs := doc.ApiComponentRefs(api); len(refs) > 0 {
+		content += renderer.GetHeader(renderer.terms["components"], 3)
+		content += renderer.formatComponentRefs(doc, refs) + "\n\n"
+	}
+
+	content += renderer.GetHeader(renderer.terms["tags"], 3)
+	for _, tag := range api.Tags {
+		content += fmt.Sprintf("* %s\n", renderer.escape(tag))
+	}
+	content += "\n"
+
+	if api.Origin != "" {
+		content += renderer.GetHeader(renderer.terms["origin"], 3)
+		content += renderer.escape(api.Origin) + "\n\n"
+	}
+	return content
+}
+
+// format the components overview section, diagrams are code blocks since mermaid directives need a sphinx extension
+func (renderer *RstRenderer) FormatComponentDiagrams(diagrams []ComponentDiagram) string {
+	content := renderer.GetTarget("components-overview")
+	content += renderer.GetHeader(renderer.terms["components_overview"], 1)
+	for _, diagram := range diagrams {
+		if diagram.Tag != "" {
+			content += renderer.GetHeader(renderer.escape(diagram.Tag), 2)
+		}
+		content += renderer.GetCodeBlock("mermaid", diagram.Source)
+		if diagram.Omitted > 0 {
+			content += renderer.escape(FormatOmittedComponents(renderer.terms, diagram.Omitted)) + "\n\n"
+		}
+	}
+	return content
+}
+
+// format the deprecations section
+func (renderer *RstRenderer) FormatDeprecations(elements []DeprecatedElement) string {
+	content := renderer.GetTarget("deprecations")
+	content += renderer.GetHeader(renderer.terms["deprecations"], 1)
+	content += renderer.GetListTable("", deprecationTableHeader, deprecationRows(renderer.terms, elements, renderer.escape))
+	return content
+}
+
+// follow an escaped name with a badge and the details of the deprecation when deprecated,
+// reStructuredText having no strikethrough
+func (renderer *RstRenderer) FormatDeprecated(name string, deprecation Deprecation) string {
+	if !deprecation.Deprecated {
+		return name
+	}
+	marked := fmt.Sprintf("%s **%s**", name, renderer.escape(renderer.terms["deprecated"]))
+	if details := FormatDeprecationDetails(renderer.terms, deprecation.DeprecationDetails); details != "" {
+		marked += fmt.Sprintf(" (%s)", renderer.escape(details))
+	}
+	return marked
+}
+
+// generate a header, the underline of which is as wide as the title
+func (renderer *RstRenderer) GetHeader(content string, level int) string {
+	underline := strings.Repeat(rstHeadingChars[level], displayWidth(content))
+	return fmt.Sprintf("%s\n%s\n\n", content, underline)
+}
+
+// generate a target which can be referenced by :ref:
+func (renderer *RstRenderer) GetTarget(label string) string {
+	return fmt.Sprintf(".. _%s:\n\n", label)
+}
+
+// generate a list-table with a localized header, the cells of which must have been escaped already
+func (renderer *RstRenderer) GetListTable(title string, header []string, rows [][]string) string {
+	table := fmt.Sprintf(".. list-table:: %s\n   :header-rows: 1\n\n", title)
+	labels := LocalizeHeader(renderer.terms, header)
+	for index, label := range labels {
+		labels[index] = renderer.escape(label)
+	}
+	table += renderer.formatListTableRow(labels)
+	for _, row := range rows {
+		table += renderer.formatListTableRow(row)
+	}
+	table += "\n"
+	return table
+}
+
+// generate a code-block directive in a given language
+func (renderer *RstRenderer) GetCodeBlock(language string, content string) string {
+	block := fmt.Sprintf(".. code-block:: %s\n\n", language)
+	for _, line := range strings.Split(content, "\n") {
+		block += "   " + line + "\n"
+	}
+	block += "\n"
+	return block
+}
+
+// format a row of a list-table, the lines of a multi-line cell are indented to the content of the cell
+func (renderer *RstRenderer) formatListTableRow(cells []string) string {
+	row := ""
+	for index, cell := range cells {
+		lines := strings.Split(cell, "\n")
+		for line := 1; line < len(lines); line++ {
+			if lines[line] != "" {
+				lines[line] = "       " + lines[line]
+			}
+		}
+		cell = strings.Join(lines, "\n")
+		if index == 0 {
+			row += fmt.Sprintf("   * - %s\n", cell)
+		} else {
+			row += fmt.Sprintf("     - %s\n", cell)
+		}
+	}
+	return row
+}
+
+// format references to components
+func (renderer *RstRenderer) formatComponentRefs(doc *Document, componentNames []string) string {
+	refs := make([]string, 0, len(componentNames))
+	for _, name := range componentNames {
+		refs = append(refs, fmt.Sprintf(":ref:`%s <%s>`", name, doc.ComponentAnchor(name)))
+	}
+	return strings.Join(refs, ", ")
+}
+
+// format a reference to an API
+func (renderer *RstRenderer) formatApiRef(doc *Document, api Api) string {
+	return fmt.Sprintf(":ref:`%s %s <%s>`", strings.ToUpper(api.Method), api.Path, doc.ApiAnchor(api))
+}
+
+// escape inline markup characters
+func (renderer *RstRenderer) escape(content string) string {
+	return rstInlineEscaper.Replace(content)
+}
+
+// width of a string in a monospaced font, wide east asian characters take two columns
+func displayWidth(content string) int {
+	width := 0
+	for _, r := range content {
+		if unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana) ||
+			(r >= 0xFF00 && r <= 0xFF60) || (r >= 0x3000 && r <= 0x303F) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+// factory for RstRenderer
+func NewRstRenderer(terms map[string]string) *RstRenderer {
+	return &RstRenderer{terms: terms}
+}
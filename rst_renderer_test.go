@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// test GetHeader in RstRenderer
+func TestRstRenderer_GetHeader(t *testing.T) {
+	t.Log("Test rst renderer - GetHeader")
+	{
+		renderer := NewRstRenderer(map[string]string{})
+		cases := map[string]string{
+			"Paths":   "Paths\n-----\n\n",
+			"API路由信息": "API路由信息\n-----------\n\n",
+		}
+		for title, expected := range cases {
+			if header := renderer.GetHeader(title, 2); header != expected {
+				t.Errorf("expected %q, got %q", expected, header)
+			}
+		}
+	}
+}
+
+// test Render in RstRenderer
+func TestRstRenderer_Render(t *testing.T) {
+	t.Log("Test rst renderer - Render")
+	{
+		analyzer := NewSwaggerAnalyzer(ENGLISH)
+		doc, err := analyzer.Extract(readTestSpec(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := NewRstRenderer(analyzer.terms).Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{
+			".. _component-pet:\n\nPet\n---\n",
+			".. list-table:: properties\n   :header-rows: 1\n",
+			".. code-block:: json\n",
+			":ref:`Pet <component-pet>`",
+			":ref:`POST /store/orders <op-post-store-orders>`",
+		} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("expected %q in rst output", expected)
+			}
+		}
+	}
+
+	t.Log("Test rst renderer - Render escapes the titles of components and APIs")
+	{
+		spec := `{"openapi": "3.0.0", "info": {"title": "Escaping", "version": "1.0.0"},
+			"paths": {"/pets": {"get": {"summary": "List *all* pets", "responses": {"200": {"description": "ok",
+				"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet_Item"}}}}}}}},
+			"components": {"schemas": {"Pet_Item": {"type": "object"}}}}`
+		analyzer := NewSwaggerAnalyzer(ENGLISH)
+		doc, err := analyzer.Extract(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := NewRstRenderer(analyzer.terms).Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"\nPet\\_Item\n---------\n", "1. List \\*all\\* pets\n"} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("expected %q in rst output:\n%s", expected, result)
+			}
+		}
+	}
+
+	t.Log("Test rst renderer - multi-line cells stay inside the list-table")
+	{
+		renderer := NewRstRenderer(map[string]string{"name": "Name", "description": "Description"})
+		table := renderer.GetListTable("parameters", []string{NAME, DESCRIPTION},
+			[][]string{{"limit", "Maximum number of pets,\n\nat most 100."}})
+		expected := ".. list-table:: parameters\n   :header-rows: 1\n\n   * - Name\n     - Description\n" +
+			"   * - limit\n     - Maximum number of pets,\n\n       at most 100.\n\n"
+		if table != expected {
+			t.Errorf("expected %q, got %q", expected, table)
+		}
+	}
+
+	t.Log("Test rst renderer - the language switcher follows the doc title")
+	{
+		content := AddLanguageSwitcher(RST_FORMAT, "#####\nPets\n#####\n\nbody\n",
+			[]LanguageLink{{Lang: ENGLISH, Current: true}, {Lang: CHINESE, Href: "api.zh.rst"}})
+		if expected := "#####\nPets\n#####\n\nen | `zh <api.zh.rst>`__\n\nbody\n"; content != expected {
+			t.Errorf("expected %q, got %q", expected, content)
+		}
+	}
+}
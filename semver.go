@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type VersionBump string
+
+const (
+	NONE_BUMP  VersionBump = "none"
+	PATCH_BUMP VersionBump = "patch"
+	MINOR_BUMP VersionBump = "minor"
+	MAJOR_BUMP VersionBump = "major"
+)
+
+// Invalid semantic version, it's not like MAJOR.MINOR.PATCH
+var InvalidSemver = errors.New("invalid semantic version")
+
+// Semver struct, a semantic version without its pre-release and build metadata
+type Semver struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// parse a semantic version like 1.2.3, v1.2 or 1.2.3-beta+build
+func ParseSemver(version string) (Semver, error) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if index := strings.IndexAny(version, "-+"); index >= 0 {
+		version = version[:index]
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return Semver{}, InvalidSemver
+	}
+	numbers := make([]int, 3)
+	for index, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return Semver{}, InvalidSemver
+		}
+		numbers[index] = number
+	}
+	return Semver{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// the next version after a bump
+func (version Semver) Bump(bump VersionBump) Semver {
+	switch bump {
+	case MAJOR_BUMP:
+		return Semver{Major: version.Major + 1}
+	case MINOR_BUMP:
+		return Semver{Major: version.Major, Minor: version.Minor + 1}
+	case PATCH_BUMP:
+		return Semver{Major: version.Major, Minor: version.Minor, Patch: version.Patch + 1}
+	default:
+		return version
+	}
+}
+
+// the bump from this version to another one, none when the other one is not greater
+func (version Semver) BumpTo(other Semver) VersionBump {
+	switch {
+	case other.Major > version.Major:
+		return MAJOR_BUMP
+	case other.Major < version.Major:
+		return NONE_BUMP
+	case other.Minor > version.Minor:
+		return MINOR_BUMP
+	case other.Minor < version.Minor:
+		return NONE_BUMP
+	case other.Patch > version.Patch:
+		return PATCH_BUMP
+	default:
+		return NONE_BUMP
+	}
+}
+
+func (version Semver) String() string {
+	return fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)
+}
+
+// rank of a bump, a greater bump satisfies the smaller ones
+func (bump VersionBump) rank() int {
+	switch bump {
+	case MAJOR_BUMP:
+		return 3
+	case MINOR_BUMP:
+		return 2
+	case PATCH_BUMP:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// BreakingReport struct, the changes between two versions of a spec and the version bump they require
+type BreakingReport struct {
+	BaseVersion      string      `json:"base_version"`
+	RevisionVersion  string      `json:"revision_version"`
+	RequiredBump     VersionBump `json:"required_bump"`
+	SuggestedVersion string      `json:"suggested_version,omitempty"`
+	// whether info.version of the revision is bumped enough
+	VersionSatisfied bool     `json:"version_satisfied"`
+	Breaking         []Change `json:"breaking"`
+	NonBreaking      []Change `json:"non_breaking"`
+}
+
+// whether the revision breaks the clients of the base version
+func (report *BreakingReport) HasBreaking() bool {
+	return len(report.Breaking) > 0
+}
+
+// the version bump required by the changes of a diff
+func RequiredBump(diff *SpecDiff, baseVersion Semver) VersionBump {
+	switch {
+	// before 1.0.0 anything may change, breaking changes only require a minor bump
+	case len(diff.Breaking()) > 0 && baseVersion.Major == 0:
+		return MINOR_BUMP
+	case len(diff.Breaking()) > 0:
+		return MAJOR_BUMP
+	case len(diff.NonBreaking()) > 0:
+		return MINOR_BUMP
+	default:
+		return NONE_BUMP
+	}
+}
+
+// report the changes of a diff and compare the required bump to the info.version of both specs,
+// the suggested version is omitted when the base version is not a semantic version
+func NewBreakingReport(diff *SpecDiff) *BreakingReport {
+	report := &BreakingReport{BaseVersion: diff.Base.Model.Info.Version,
+		RevisionVersion: diff.Revision.Model.Info.Version, Breaking: diff.Breaking(),
+		NonBreaking: diff.NonBreaking()}
+
+	baseVersion, err := ParseSemver(report.BaseVersion)
+	if err != nil {
+		report.RequiredBump = RequiredBump(diff, Semver{Major: 1})
+		return report
+	}
+	report.RequiredBump = RequiredBump(diff, baseVersion)
+	report.SuggestedVersion = baseVersion.Bump(report.RequiredBump).String()
+	if revisionVersion, err := ParseSemver(report.RevisionVersion); err == nil {
+		report.VersionSatisfied = baseVersion.BumpTo(revisionVersion).rank() >= report.RequiredBump.rank()
+	}
+	return report
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+)
+
+// test ParseSemver and Bump
+func TestParseSemver(t *testing.T) {
+	t.Log("Test semver - ParseSemver")
+	{
+		cases := map[string]string{"1.2.3": "1.2.3", "v2.1": "2.1.0", "0.9.1-beta+42": "0.9.1"}
+		for version, expected := range cases {
+			semver, err := ParseSemver(version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if semver.String() != expected {
+				t.Errorf("expected %s, got %s", expected, semver)
+			}
+		}
+		if _, err := ParseSemver("v1.x"); err == nil {
+			t.Error("expected an error for a version which is not semantic")
+		}
+	}
+
+	t.Log("Test semver - Bump")
+	{
+		version := Semver{Major: 1, Minor: 4, Patch: 2}
+		cases := map[VersionBump]string{MAJOR_BUMP: "2.0.0", MINOR_BUMP: "1.5.0", PATCH_BUMP: "1.4.3", NONE_BUMP: "1.4.2"}
+		for bump, expected := range cases {
+			if bumped := version.Bump(bump).String(); bumped != expected {
+				t.Errorf("expected %s, got %s", expected, bumped)
+			}
+		}
+	}
+}
+
+// test NewBreakingReport
+func TestNewBreakingReport(t *testing.T) {
+	diff, err := DiffSpecs(NewSwaggerAnalyzer(ENGLISH), "testdata/petstore.json", "testdata/petstore_v2.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Test breaking report - breaking changes with a major bump")
+	{
+		report := NewBreakingReport(diff)
+		if !report.HasBreaking() || report.RequiredBump != MAJOR_BUMP || report.SuggestedVersion != "2.0.0" {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if !report.VersionSatisfied {
+			t.Errorf("2.0.0 should satisfy a major bump from 1.0.0")
+		}
+	}
+
+	t.Log("Test breaking report - breaking changes without a version bump")
+	{
+		diff.Revision.Model.Info.Version = "1.1.0"
+		report := NewBreakingReport(diff)
+		if report.VersionSatisfied {
+			t.Errorf("1.1.0 should not satisfy a major bump from 1.0.0")
+		}
+	}
+
+	t.Log("Test breaking report - breaking changes before 1.0.0")
+	{
+		diff.Base.Model.Info.Version = "0.3.0"
+		if report := NewBreakingReport(diff); report.RequiredBump != MINOR_BUMP || report.SuggestedVersion != "0.4.0" {
+			t.Errorf("unexpected report %+v", report)
+		}
+	}
+}
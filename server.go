@@ -0,0 +1,196 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	// default limit of the size of a posted spec
+	DEFAULT_MAX_BODY_SIZE = 10 << 20
+	// default time given to in-flight requests on shutdown
+	DEFAULT_SHUTDOWN_TIMEOUT = 10 * time.Second
+)
+
+// ConvertServer struct, exposing the transformer pipeline over http
+type ConvertServer struct {
+	MaxBodySize int64 // limit of the size of a posted spec in bytes
+}
+
+// routes of the server
+func (server *ConvertServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/convert", server.handleConvert)
+	mux.HandleFunc("/healthz", server.handleHealth)
+	return mux
+}
+
+func (server *ConvertServer) handleHealth(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(writer, "ok")
+}
+
+// convert a posted json or yaml spec, options are given by the lang, format and layout query parameters,
+// a split layout is returned as a zip archive of its files
+func (server *ConvertServer) handleConvert(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	transformer, err := server.newTransformer(request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(writer, request.Body, server.MaxBodySize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(writer, fmt.Sprintf("spec larger than %d bytes", server.MaxBodySize),
+			http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// yaml specs are converted into json, the body is sniffed unless it is declared as json since clients like
+	// curl -d send specs as form data
+	transformer.JsonContent = string(body)
+	if mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type")); mediaType != "application/json" {
+		if transformer.JsonContent, err = DecodeSpec(transformer.JsonContent); err != nil {
+			http.Error(writer, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+	if err := analyzeSafely(transformer); err != nil {
+		http.Error(writer, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if transformer.Layout == SPLIT_LAYOUT {
+		archive, err := zipOutputFiles(transformer.OutputFiles[transformer.LangType])
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/zip")
+		writer.Write(archive)
+		return
+	}
+	writer.Header().Set("Content-Type", transformer.Format.ContentType())
+	writer.Write([]byte(transformer.OutputContent))
+}
+
+// create a transformer from the query parameters of a request
+func (server *ConvertServer) newTransformer(request *http.Request) (*Transformer, error) {
+	query := request.URL.Query()
+	langType, outputFormat, outputLayout := DEFAULT_LANGUAGE, MARKDOWN_FORMAT, SINGLE_LAYOUT
+	var err error
+	if name := query.Get("lang"); name != "" {
+		if langType, err = ParseLanguage(name); err != nil {
+			return nil, err
+		}
+	}
+	// an unknown language would be fatal to NewTransformer
+	if _, err := defaultLanguageRegistry.Resolve(langType); err != nil {
+		return nil, err
+	}
+	if name := query.Get("format"); name != "" {
+		if outputFormat, err = ParseOutputFormat(name); err != nil {
+			return nil, err
+		}
+	}
+	if name := query.Get("layout"); name != "" {
+		if outputLayout, err = ParseOutputLayout(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if outputLayout == SPLIT_LAYOUT && outputFormat != MARKDOWN_FORMAT {
+		return nil, errors.New("the split layout only supports the markdown format")
+	}
+
+	transformer := NewTransformer("", "", LOCAL_SOURCE, langType, outputFormat)
+	transformer.Layout = outputLayout
+	transformer.SplitComponents = query.Get("split_components") == "true"
+	return transformer, nil
+}
+
+// analyze a spec, the analyzer assumes a valid spec and panics on malformed ones
+func analyzeSafely(transformer *Transformer) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("malformed spec: %v", recovered)
+		}
+	}()
+	return transformer.Analyze()
+}
+
+// archive the files of a split layout
+func zipOutputFiles(files []OutputFile) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	archive := zip.NewWriter(buffer)
+	for _, file := range files {
+		entry, err := archive.Create(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := entry.Write([]byte(file.Content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// factory for ConvertServer
+func NewConvertServer(maxBodySize int64) *ConvertServer {
+	return &ConvertServer{MaxBodySize: maxBodySize}
+}
+
+// run the serve command until interrupted, in-flight requests are given time to complete
+func runServeCommand(args []string) error {
+	flagSet := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flagSet.String("addr", ":8080", "Address to listen on.")
+	maxBodySize := flagSet.Int64("max-body", DEFAULT_MAX_BODY_SIZE, "Limit of the size of a posted spec in bytes.")
+	shutdownTimeout := flagSet.Duration("shutdown-timeout", DEFAULT_SHUTDOWN_TIMEOUT,
+		"Time given to in-flight requests on shutdown.")
+	serveLangDir := flagSet.String("lang-dir", "", "Directory of <tag>.json language packs to load.")
+	flagSet.Parse(args)
+
+	if err := LoadLanguagePacks(*serveLangDir, "", ""); err != nil {
+		return err
+	}
+	convertServer := NewConvertServer(*maxBodySize)
+	httpServer := &http.Server{Addr: *addr, Handler: convertServer.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+		ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+		defer cancel()
+		shutdownErr <- httpServer.Shutdown(ctx)
+	}()
+
+	log.Printf("listening on %s", *addr)
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return <-shutdownErr
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// post a spec to the convert endpoint of a server
+func postSpec(t *testing.T, server *ConvertServer, query string, contentType string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/convert"+query, strings.NewReader(body))
+	request.Header.Set("Content-Type", contentType)
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	return recorder
+}
+
+// test the convert endpoint of ConvertServer
+func TestConvertServer_Convert(t *testing.T) {
+	server := NewConvertServer(DEFAULT_MAX_BODY_SIZE)
+	spec := readTestSpec(t)
+
+	t.Log("Test convert server - convert to html in chinese")
+	{
+		response := postSpec(t, server, "?lang=zh&format=html", "application/json", spec)
+		if response.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", response.Code, response.Body)
+		}
+		if !strings.HasPrefix(response.Header().Get("Content-Type"), "text/html") {
+			t.Errorf("unexpected content type %s", response.Header().Get("Content-Type"))
+		}
+		if !strings.Contains(response.Body.String(), "概述") {
+			t.Errorf("the doc is not localized")
+		}
+	}
+
+	t.Log("Test convert server - convert to the split layout")
+	{
+		response := postSpec(t, server, "?layout=split", "application/json", spec)
+		if response.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", response.Code, response.Body)
+		}
+		archive, err := zip.NewReader(bytes.NewReader(response.Body.Bytes()), int64(response.Body.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(archive.File) != 4 || archive.File[0].Name != INDEX_FILE_NAME {
+			t.Errorf("unexpected archive files %v", archive.File)
+		}
+	}
+
+	t.Log("Test convert server - convert a yaml spec")
+	{
+		yamlSpec := "openapi: 3.0.0\ninfo:\n  title: Yaml Petstore\n  version: 1.0.0\n" +
+			"paths:\n  /pets:\n    get:\n      operationId: listPets\n      tags: [pets]\n" +
+			"      responses:\n        '200':\n          description: A list of pets\n"
+		for _, contentType := range []string{"application/yaml", "text/x-yaml", "", "application/x-www-form-urlencoded", "text/plain"} {
+			response := postSpec(t, server, "", contentType, yamlSpec)
+			if response.Code != http.StatusOK {
+				t.Fatalf("%s: expected 200, got %d: %s", contentType, response.Code, response.Body)
+			}
+			for _, expected := range []string{"# Yaml Petstore", "GET /pets", "`listPets`", "A list of pets"} {
+				if !strings.Contains(response.Body.String(), expected) {
+					t.Errorf("%s: expected %q in:\n%s", contentType, expected, response.Body)
+				}
+			}
+		}
+		if response := postSpec(t, server, "", "text/plain", spec); response.Code != http.StatusOK {
+			t.Errorf("a json spec sent as text/plain should convert, got %d: %s", response.Code, response.Body)
+		}
+	}
+
+	t.Log("Test convert server - rejected requests")
+	{
+		cases := []struct {
+			query       string
+			contentType string
+			body        string
+			status      int
+		}{
+			{"", "application/yaml", "openapi: [3.0.0", http.StatusUnprocessableEntity},
+			{"", "application/json", "openapi: 3.0.0\npaths: {}\n", http.StatusUnprocessableEntity},
+			{"?lang=ja", "application/json", spec, http.StatusBadRequest},
+			{"?format=pdf", "application/json", spec, http.StatusBadRequest},
+			{"", "application/json", `{"openapi": "3.0.0", "paths": "/pets"}`,
+				http.StatusUnprocessableEntity},
+		}
+		for _, c := range cases {
+			if response := postSpec(t, server, c.query, c.contentType, c.body); response.Code != c.status {
+				t.Errorf("%s %s: expected %d, got %d", c.query, c.contentType, c.status, response.Code)
+			}
+		}
+		small := NewConvertServer(16)
+		if response := postSpec(t, small, "", "application/json", spec); response.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected 413, got %d", response.Code)
+		}
+	}
+
+	t.Log("Test convert server - healthz")
+	{
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		if recorder.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", recorder.Code)
+		}
+	}
+}
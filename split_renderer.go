@@ -0,0 +1,208 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type OutputLayout int
+
+const (
+	SINGLE_LAYOUT OutputLayout = 0
+	SPLIT_LAYOUT  OutputLayout = 1
+
+	// name of the index page of a split doc
+	INDEX_FILE_NAME = "index.md"
+	// name of the file holding all components of a split doc
+	COMPONENTS_FILE_NAME = "components.md"
+)
+
+// Invalid output layout
+var InvalidOutputLayout = errors.New("invalid output layout")
+
+// OutputFile struct, a rendered file and its path
+type OutputFile struct {
+	Path    string
+	Content string
+}
+
+// parse the name of an output layout given on the command line
+func ParseOutputLayout(name string) (OutputLayout, error) {
+	switch strings.ToLower(name) {
+	case "single":
+		return SINGLE_LAYOUT, nil
+	case "split":
+		return SPLIT_LAYOUT, nil
+	default:
+		return SINGLE_LAYOUT, InvalidOutputLayout
+	}
+}
+
+// SplitRenderer struct, rendering a doc as an index page, a page per tag and component pages in markdown
+type SplitRenderer struct {
+	SplitComponents bool // one file per component instead of a single components file
+
+	analyzer  *SwaggerAnalyzer
+	generator *MdGenerator
+}
+
+// render an extracted doc into files, the paths of which are relative to the output directory
+func (renderer *SplitRenderer) RenderFiles(doc *Document) ([]OutputFile, error) {
+	tagNames, groups := doc.ApisByTag()
+	files := make([]OutputFile, 0)
+	files = append(files, OutputFile{Path: INDEX_FILE_NAME, Content: renderer.FormatIndex(doc, tagNames, groups)})
+
+	apiIndex := 1
+	for _, tagName := range tagNames {
+		if len(groups[tagName]) == 0 {
+			continue
+		}
+		content := renderer.FormatTag(doc, tagName, groups[tagName], apiIndex)
+		apiIndex += len(groups[tagName])
+		files = append(files, OutputFile{Path: renderer.TagFile(doc, tagName), Content: content})
+	}
+
+	if renderer.SplitComponents {
+		for _, component := range doc.Components {
+			content := renderer.generator.GetHeader(component.Name, H1, INDENT_0) + "\n\n"
+			content += renderer.FormatComponent(doc, component)
+			content += renderer.formatBackLink()
+			files = append(files, OutputFile{Path: renderer.ComponentFile(doc, component.Name), Content: content})
+		}
+	} else if len(doc.Components) > 0 {
+		content := renderer.generator.GetHeader(renderer.analyzer.terms["components"], H1, INDENT_0) + "\n\n"
+		for _, component := range doc.Components {
+			content += renderer.FormatComponent(doc, component)
+		}
+		content += renderer.formatBackLink()
+		files = append(files, OutputFile{Path: COMPONENTS_FILE_NAME, Content: content})
+	}
+	return files, nil
+}
+
+// format the index page, with the overview and links to every tag, API and component
+func (renderer *SplitRenderer) FormatIndex(doc *Document, tagNames []string, groups map[string][]Api) string {
+	content := renderer.generator.GetHeader(doc.Model.Info.Title, H1, INDENT_0) + "\n"
+	content += renderer.analyzer.AnalyzeOverview(doc.Model) + "\n"
+
+	content += renderer.generator.GetHeader(renderer.analyzer.terms["paths"], H2, INDENT_0) + "\n"
+	for _, tagName := range tagNames {
+		if len(groups[tagName]) == 0 {
+			continue
+		}
+		tagLink := renderer.generator.GetLink(tagName, renderer.TagFile(doc, tagName))
+		content += renderer.generator.GetListItem(tagLink, INDENT_0) + "\n"
+		for _, api := range groups[tagName] {
+			apiLink := renderer.generator.GetLink(fmt.Sprintf("%s %s", strings.ToUpper(api.Method), api.Path),
+				renderer.ApiHref(doc, api))
+			content += renderer.generator.GetListItem(apiLink, INDENT_1) + "\n"
+		}
+	}
+	content += "\n"
+
+	if len(doc.ComponentDiagrams) > 0 {
+		content += renderer.analyzer.FormatComponentDiagrams(doc.ComponentDiagrams)
+	}
+	if len(doc.Components) > 0 {
+		content += renderer.generator.GetHeader(renderer.analyzer.terms["components"], H2, INDENT_0) + "\n"
+		for _, component := range doc.Components {
+			componentLink := renderer.generator.GetLink(component.Name, renderer.ComponentHref(doc, component.Name))
+			content += renderer.generator.GetListItem(componentLink, INDENT_0) + "\n"
+		}
+		content += "\n"
+	}
+
+	if len(doc.Deprecations) > 0 {
+		content += renderer.analyzer.FormatDeprecations(doc.Deprecations) + "\n"
+	}
+	return content
+}
+
+// format the page of a tag, holding all APIs whose first tag it is
+func (renderer *SplitRenderer) FormatTag(doc *Document, tagName string, apis []Api, firstIndex int) string {
+	content := renderer.generator.GetHeader(tagName, H1, INDENT_0) + "\n\n"
+	for _, tag := range doc.Model.Tags {
+		if tag.Name == tagName && tag.Description != "" {
+			content += tag.Description + "\n\n"
+		}
+	}
+
+	for index, api := range apis {
+		content += renderer.generator.GetAnchor(doc.ApiAnchor(api)) + "\n"
+		content += renderer.analyzer.FormatAPI(firstIndex+index, api)
+		if refs := doc.ApiComponentRefs(api); len(refs) > 0 {
+			componentsHeader := renderer.generator.GetHeader(renderer.analyzer.terms["components"], H4, INDENT_1)
+			content += componentsHeader + "\n"
+			for _, ref := range refs {
+				componentLink := renderer.generator.GetLink(ref, renderer.ComponentHref(doc, ref))
+				content += renderer.generator.GetListItem(componentLink, INDENT_1) + "\n"
+			}
+		}
+		content += "\n"
+	}
+	content += renderer.formatBackLink()
+	return content
+}
+
+// format a component, with links to the components it references and the APIs using it
+func (renderer *SplitRenderer) FormatComponent(doc *Document, component Component) string {
+	content := renderer.generator.GetAnchor(doc.ComponentAnchor(component.Name)) + "\n"
+	content += renderer.analyzer.FormatComponent(&component) + "\n"
+
+	if refs := doc.ComponentRefs(component); len(refs) > 0 {
+		links := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			links = append(links, renderer.generator.GetLink(ref, renderer.ComponentHref(doc, ref)))
+		}
+		content += renderer.generator.GetListItem(fmt.Sprintf("%s : %s",
+			renderer.analyzer.terms["references"], strings.Join(links, ", ")), INDENT_1) + "\n"
+	}
+	if usages := doc.ComponentUsages(component.Name); len(usages) > 0 {
+		links := make([]string, 0, len(usages))
+		for _, api := range usages {
+			links = append(links, renderer.generator.GetLink(fmt.Sprintf("%s %s",
+				strings.ToUpper(api.Method), api.Path), renderer.ApiHref(doc, api)))
+		}
+		content += renderer.generator.GetListItem(fmt.Sprintf("%s : %s",
+			renderer.analyzer.terms["used_by"], strings.Join(links, ", ")), INDENT_1) + "\n"
+	}
+	return content + "\n"
+}
+
+// file of a tag page
+func (renderer *SplitRenderer) TagFile(doc *Document, tagName string) string {
+	return doc.TagAnchor(tagName) + ".md"
+}
+
+// file holding a component
+func (renderer *SplitRenderer) ComponentFile(doc *Document, componentName string) string {
+	if renderer.SplitComponents {
+		return doc.ComponentAnchor(componentName) + ".md"
+	}
+	return COMPONENTS_FILE_NAME
+}
+
+// relative link to an API, which lives on the page of its first tag
+func (renderer *SplitRenderer) ApiHref(doc *Document, api Api) string {
+	tagName := "default"
+	if len(api.Tags) > 0 {
+		tagName = api.Tags[0]
+	}
+	return renderer.TagFile(doc, tagName) + "#" + doc.ApiAnchor(api)
+}
+
+// relative link to a component
+func (renderer *SplitRenderer) ComponentHref(doc *Document, componentName string) string {
+	return renderer.ComponentFile(doc, componentName) + "#" + doc.ComponentAnchor(componentName)
+}
+
+// format the link back to the index page
+func (renderer *SplitRenderer) formatBackLink() string {
+	return renderer.generator.GetLink(renderer.analyzer.terms["overview"], INDEX_FILE_NAME) + "\n"
+}
+
+// factory for SplitRenderer
+func NewSplitRenderer(analyzer *SwaggerAnalyzer, splitComponents bool) *SplitRenderer {
+	return &SplitRenderer{SplitComponents: splitComponents, analyzer: analyzer, generator: NewMdGenerator()}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// spec with chinese tag names and tag names colliding once turned into anchors
+const unicodeTagSpec = `{
+	"openapi": "3.0.0",
+	"info": {"title": "宠物商店", "version": "1.0.0"},
+	"tags": [{"name": "宠物"}, {"name": "订单"}, {"name": "pet store"}, {"name": "pet-store"}],
+	"paths": {
+		"/pets": {"get": {"operationId": "listPets", "tags": ["宠物"], "responses": {"200": {"description": "ok"}}}},
+		"/orders": {"get": {"operationId": "listOrders", "tags": ["订单"], "responses": {"200": {"description": "ok"}}}},
+		"/stores": {"get": {"operationId": "listStores", "tags": ["pet store"], "responses": {"200": {"description": "ok"}}}},
+		"/stores/{id}": {"get": {"operationId": "getStore", "tags": ["pet-store"], "responses": {"200": {"description": "ok"}}}}
+	}
+}`
+
+// test RenderFiles in SplitRenderer
+func TestSplitRenderer_RenderFiles(t *testing.T) {
+	doc, err := NewSwaggerAnalyzer(ENGLISH).Extract(readTestSpec(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("Test split renderer - RenderFiles with a components file")
+	{
+		files, err := NewSplitRenderer(NewSwaggerAnalyzer(ENGLISH), false).RenderFiles(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents := make(map[string]string)
+		for _, file := range files {
+			contents[file.Path] = file.Content
+		}
+		for _, path := range []string{"index.md", "tag-pets.md", "tag-store.md", "components.md"} {
+			if _, ok := contents[path]; !ok {
+				t.Errorf("missing file %s", path)
+			}
+		}
+		if !strings.Contains(contents["index.md"], "[GET /pets/{petId}](tag-pets.md#op-get-pets-petid)") {
+			t.Errorf("the index does not link the operations")
+		}
+		if !strings.Contains(contents["tag-pets.md"], "[Pet](components.md#component-pet)") {
+			t.Errorf("the tag page does not link the components")
+		}
+		if !strings.Contains(contents["components.md"], "[POST /store/orders](tag-store.md#op-post-store-orders)") {
+			t.Errorf("the components do not link back to the operations")
+		}
+	}
+
+	t.Log("Test split renderer - RenderFiles with a file per component")
+	{
+		files, err := NewSplitRenderer(NewSwaggerAnalyzer(ENGLISH), true).RenderFiles(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths := make([]string, 0, len(files))
+		for _, file := range files {
+			paths = append(paths, file.Path)
+			if file.Path == "tag-pets.md" && !strings.Contains(file.Content, "(component-pet.md#component-pet)") {
+				t.Errorf("the tag page does not link the component file")
+			}
+		}
+		expected := "index.md tag-pets.md tag-store.md component-order.md component-pet.md"
+		if strings.Join(paths, " ") != expected {
+			t.Errorf("expected %s, got %v", expected, paths)
+		}
+	}
+
+	t.Log("Test split renderer - RenderFiles with chinese and colliding tag names")
+	{
+		unicodeDoc, err := NewSwaggerAnalyzer(CHINESE).Extract(unicodeTagSpec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files, err := NewSplitRenderer(NewSwaggerAnalyzer(CHINESE), false).RenderFiles(unicodeDoc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths := make([]string, 0, len(files))
+		for _, file := range files {
+			paths = append(paths, file.Path)
+		}
+		expected := "index.md tag-宠物.md tag-订单.md tag-pet-store.md tag-pet-store-2.md"
+		if strings.Join(paths, " ") != expected {
+			t.Errorf("expected %s, got %v", expected, paths)
+		}
+		if !strings.Contains(files[0].Content, "[GET /stores/{id}](tag-pet-store-2.md#op-get-stores-id)") {
+			t.Errorf("the index does not link the suffixed tag page:\n%s", files[0].Content)
+		}
+	}
+}
+
+// test writing the split layout in several languages
+func TestTransformer_SplitLayout(t *testing.T) {
+	t.Log("Test transformer - Run with the split layout")
+	{
+		outputDir := t.TempDir()
+		transformer := NewTransformer("testdata/petstore.json", outputDir, LOCAL_SOURCE, ENGLISH, MARKDOWN_FORMAT)
+		transformer.Layout = SPLIT_LAYOUT
+		transformer.Languages = []LanguageType{ENGLISH, CHINESE}
+		if err := transformer.Run(); err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range []string{"en/index.md", "zh/tag-store.md", "zh/components.md"} {
+			if _, err := os.Stat(filepath.Join(outputDir, path)); err != nil {
+				t.Errorf("missing output file %s", path)
+			}
+		}
+		if len(transformer.OutputPaths()) != 8 {
+			t.Errorf("expected 8 output paths, got %v", transformer.OutputPaths())
+		}
+	}
+
+	t.Log("Test transformer - the split layout with another format")
+	{
+		transformer := NewTransformer("testdata/petstore.json", t.TempDir(), LOCAL_SOURCE, ENGLISH, HTML_FORMAT)
+		transformer.Layout = SPLIT_LAYOUT
+		if err := transformer.Run(); err == nil {
+			t.Error("expected an error for the html format")
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// default templates reproducing the built-in markdown layout
+//
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// sections of a document which can be overridden by a template of the same name
+var templateSections = []string{"document", "overview", "component", "operation"}
+
+var markdownEscaper = strings.NewReplacer("\\", "\\\\", "|", "\\|", "*", "\\*", "_", "\\_",
+	"`", "\\`", "<", "\\<", ">", "\\>")
+
+var markdownLineBreaks = strings.NewReplacer("\r\n", "<br>", "\n", "<br>")
+
+// escape the content of a markdown table cell, its line breaks become <br> so the row stays on one line
+func escapeMarkdownCell(cell string) string {
+	return markdownLineBreaks.Replace(markdownEscaper.Replace(strings.TrimSpace(cell)))
+}
+
+// an API with its position in the paths section, passed to the operation template
+type NumberedApi struct {
+	Index int
+	Api   Api
+}
+
+type TemplateRenderer struct {
+	terms     map[string]string // terms associated with language settings
+	template  *template.Template
+	generator *MdGenerator
+}
+
+// render an extracted doc with the document template
+func (renderer *TemplateRenderer) Render(doc *Document) (string, error) {
+	// anchors are unique within a doc, so the anchor functions are bound to the doc being rendered
+	documentTemplate, err := renderer.template.Lookup("document").Clone()
+	if err != nil {
+		return "", err
+	}
+	documentTemplate.Funcs(template.FuncMap{"apiAnchor": doc.ApiAnchor, "componentAnchor": doc.ComponentAnchor})
+	buffer := &bytes.Buffer{}
+	if err := documentTemplate.ExecuteTemplate(buffer, "document", doc); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// load the default templates, then the templates in a directory overriding the sections of the same name
+func (renderer *TemplateRenderer) LoadTemplates(templateDir string) error {
+	renderer.template = template.New("document").Funcs(renderer.funcs())
+	for _, section := range templateSections {
+		content, err := defaultTemplates.ReadFile("templates/" + section + ".tmpl")
+		if err != nil {
+			return err
+		}
+		if _, err := renderer.template.New(section).Parse(string(content)); err != nil {
+			return err
+		}
+	}
+
+	if templateDir == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(templateDir, "*.tmpl"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(filepath.Base(file), ".tmpl")
+		if _, err := renderer.template.New(name).Parse(string(content)); err != nil {
+			return fmt.Errorf("template %s: %v", file, err)
+		}
+	}
+	return nil
+}
+
+// helper functions available in templates
+func (renderer *TemplateRenderer) funcs() template.FuncMap {
+	return template.FuncMap{
+		"term":            func(key string) string { return renderer.terms[key] },
+		"serverName":      func(index int) string { return LocalizeServerName(renderer.terms, index) },
+		"escape":          markdownEscaper.Replace,
+		"upper":           strings.ToUpper,
+		"lower":           strings.ToLower,
+		"join":            strings.Join,
+		"trim":            strings.TrimSpace,
+		"list":            func(items ...string) []string { return items },
+		"numbered":        func(index int, api Api) NumberedApi { return NumberedApi{Index: index + 1, Api: api} },
+		"apiAnchor":       GetApiAnchor,
+		"componentAnchor": GetComponentAnchor,
+		"indent":          renderer.indent,
+		"codeBlock":       renderer.codeBlock,
+		"table":           renderer.table,
+		"parametersTable": renderer.parametersTable,
+		"responsesTable":  renderer.responsesTable,
+		"propertiesTable": renderer.propertiesTable,
+		"deprecated": func(name string, deprecation Deprecation) string {
+			return FormatMarkdownDeprecated(renderer.terms, name, deprecation)
+		},
+		"deprecationsTable": renderer.deprecationsTable,
+		"omittedComponents": func(omitted int) string {
+			return FormatOmittedComponents(renderer.terms, omitted)
+		},
+	}
+}
+
+// indent every non empty line by a number of indent levels
+func (renderer *TemplateRenderer) indent(level int, content string) string {
+	indent := strings.Repeat(" ", level*4)
+	lines := strings.Split(content, "\n")
+	for index, line := range lines {
+		if len(line) > 0 {
+			lines[index] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// generate a fenced code block in a given language
+func (renderer *TemplateRenderer) codeBlock(language string, code string) string {
+	return fmt.Sprintf("```%s\n%s\n```", language, code)
+}
+
+// generate a markdown table with a localized header, every row is a slice of cells ordered as the header
+func (renderer *TemplateRenderer) table(header []string, rows [][]string) string {
+	escaped := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, escapeMarkdownCell(cell))
+		}
+		escaped = append(escaped, cells)
+	}
+	return renderer.escapedTable(header, escaped)
+}
+
+// generate a markdown table from cells escaped already
+func (renderer *TemplateRenderer) escapedTable(header []string, rows [][]string) string {
+	lines := make([]TableLine, 0, len(rows))
+	for _, row := range rows {
+		line := TableLine{Content: make(map[string]string)}
+		for index, cell := range row {
+			if index < len(header) {
+				line.Set(header[index], cell)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return renderer.generator.GetLabeledTable(header, LocalizeHeader(renderer.terms, header), lines, INDENT_0)
+}
+
+// generate the parameters table of an API
+func (renderer *TemplateRenderer) parametersTable(api Api) string {
+	rows := make([][]string, 0, len(api.Parameters))
+	for _, parameter := range api.Parameters {
+		rows = append(rows, []string{markdownEscaper.Replace(parameter.In),
+			FormatMarkdownDeprecated(renderer.terms, markdownEscaper.Replace(parameter.Name), parameter.Deprecation),
+			markdownEscaper.Replace(parameter.Description), markdownEscaper.Replace(parameter.Type)})
+	}
+	return renderer.escapedTable(parameterTableHeader, rows)
+}
+
+// generate the responses table of an API
+func (renderer *TemplateRenderer) responsesTable(api Api) string {
+	rows := make([][]string, 0, len(api.Responses))
+	for _, response := range api.Responses {
+		rows = append(rows, []string{response.StatusCode, response.Description,
+			LocalizeSchema(renderer.terms, response.Schema)})
+	}
+	return renderer.table(responseTableHeader, rows)
+}
+
+// generate the properties table of a component
+func (renderer *TemplateRenderer) propertiesTable(component Component) string {
+	rows := make([][]string, 0, len(component.Properties))
+	for _, property := range component.Properties {
+		rows = append(rows, []string{
+			FormatMarkdownDeprecated(renderer.terms, markdownEscaper.Replace(property.Name), property.Deprecation),
+			markdownEscaper.Replace(property.Type), markdownEscaper.Replace(LocalizeBool(renderer.terms, property.Required)),
+			markdownEscaper.Replace(property.Description), markdownEscaper.Replace(property.Example)})
+	}
+	return renderer.escapedTable(componentTableHeader, rows)
+}
+
+// generate the table of the deprecations section
+func (renderer *TemplateRenderer) deprecationsTable(elements []DeprecatedElement) string {
+	return renderer.escapedTable(deprecationTableHeader,
+		deprecationRows(renderer.terms, elements, markdownEscaper.Replace))
+}
+
+// factory for TemplateRenderer, templates in templateDir override the default ones
+func NewTemplateRenderer(terms map[string]string, templateDir string) (*TemplateRenderer, error) {
+	renderer := &TemplateRenderer{terms: terms, generator: NewMdGenerator()}
+	renderer.generator.NoContent = terms["no_content"]
+	if err := renderer.LoadTemplates(templateDir); err != nil {
+		return nil, err
+	}
+	return renderer, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// test Render in TemplateRenderer with the default templates
+func TestTemplateRenderer_RenderDefault(t *testing.T) {
+	t.Log("Test template renderer - Render with default templates")
+	{
+		analyzer := NewSwaggerAnalyzer(ENGLISH)
+		doc, err := analyzer.Extract(readTestSpec(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		renderer, err := NewTemplateRenderer(analyzer.terms, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := renderer.Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{
+			"# Swagger Petstore\n",
+			"## Components\n",
+			"1. ***List all pets***\n\n    Returns the pets of the store.\n\n    Results are **paged**, see `limit`.\n\n" +
+				"    + Operation ID : `listPets`\n",
+			"2. ***createPet***\n\n    + Operation ID : `createPet`\n",
+			"    |query|limit|How many items to return|integer|",
+			"        |tags|array\\<string\\>|False||/|",
+			"+ Pet\n\n    A pet of the store\n\n    + Type : `object`\n",
+			"        |name|string|True|Name of the pet|doggie|",
+		} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("expected %q in template output", expected)
+			}
+		}
+	}
+}
+
+// test Render in TemplateRenderer with a section overridden from a directory
+func TestTemplateRenderer_RenderOverride(t *testing.T) {
+	t.Log("Test template renderer - Render with an overridden operation template")
+	{
+		templateDir := t.TempDir()
+		operation := "- {{.Api.OperationId}} [{{upper .Api.Method}}](#{{apiAnchor .Api}}) {{escape \"a|b\"}}\n"
+		if err := ioutil.WriteFile(filepath.Join(templateDir, "operation.tmpl"), []byte(operation), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		analyzer := NewSwaggerAnalyzer(ENGLISH)
+		doc, err := analyzer.Extract(readTestSpec(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		renderer, err := NewTemplateRenderer(analyzer.terms, templateDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := renderer.Render(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(result, "- showPetById [GET](#op-get-pets-petid) a\\|b") {
+			t.Errorf("overridden operation template not used:\n%s", result)
+		}
+		if !strings.Contains(result, "## Overview") {
+			t.Errorf("default overview template not used:\n%s", result)
+		}
+
+		t.Log("Templates combined with another format are rejected")
+		{
+			transformer := NewTransformer("testdata/petstore.json", t.TempDir(), LOCAL_SOURCE, ENGLISH, HTML_FORMAT)
+			transformer.TemplateDir = templateDir
+			if err := transformer.Run(); err == nil {
+				t.Errorf("expected an error for templates with the html format")
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// test rendering several languages in a single run
+func TestTransformer_AnalyzeLanguages(t *testing.T) {
+	t.Log("Test transformer - Analyze in several languages")
+	{
+		outputDir := t.TempDir()
+		transformer := NewTransformer("testdata/petstore.json", outputDir, LOCAL_SOURCE, ENGLISH, MARKDOWN_FORMAT)
+		transformer.Languages = []LanguageType{ENGLISH, CHINESE}
+		transformer.LanguageSwitcher = true
+		if err := transformer.Run(); err != nil {
+			t.Fatal(err)
+		}
+
+		expectedPaths := []string{filepath.Join(outputDir, "api.en.md"), filepath.Join(outputDir, "api.zh.md")}
+		for index, outputPath := range transformer.OutputPaths() {
+			if outputPath != expectedPaths[index] {
+				t.Errorf("expected %s, got %s", expectedPaths[index], outputPath)
+			}
+		}
+
+		if !strings.HasPrefix(transformer.OutputContents[ENGLISH], "en | [zh](api.zh.md)\n") {
+			t.Errorf("missing language switcher in the english doc")
+		}
+		if !strings.Contains(transformer.OutputContents[CHINESE], "## 概述") {
+			t.Errorf("the chinese doc is not localized")
+		}
+		if transformer.OutputContent != transformer.OutputContents[ENGLISH] {
+			t.Errorf("the output content should be the doc of the first language")
+		}
+	}
+}
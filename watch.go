@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// default interval between two polls of the watched files
+	DEFAULT_WATCH_INTERVAL = 500 * time.Millisecond
+	// default quiet period after the last change before the docs are regenerated
+	DEFAULT_WATCH_DEBOUNCE = 200 * time.Millisecond
+)
+
+// Watching needs local files, it's unable to poll a web url
+var InvalidWatchSource = errors.New("watch mode requires a local input")
+
+// state of a watched file, a file is changed when its modification time or size changes
+type fileState struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+// Watcher struct, polling the input of a transformer and the files it references to regenerate the docs on change
+type Watcher struct {
+	Transformer *Transformer
+	Interval    time.Duration
+	Debounce    time.Duration
+	// called after every run with the error of the run, diagnostics are logged when nil
+	OnRun func(err error)
+	// render the docs on change without writing the outputs
+	DryRun bool
+
+	files map[string]fileState
+}
+
+// run the transformer, then poll until stop is closed, errors of a run are reported without stopping
+func (watcher *Watcher) Watch(stop <-chan struct{}) error {
+	if watcher.Transformer.ContentFrom != LOCAL_SOURCE {
+		return InvalidWatchSource
+	}
+	watcher.run()
+
+	ticker := time.NewTicker(watcher.Interval)
+	defer ticker.Stop()
+	pending := false
+	lastChange := time.Time{}
+	for {
+		select {
+		case <-stop:
+			return nil
+		case now := <-ticker.C:
+			if watcher.changed() {
+				pending, lastChange = true, now
+			}
+			if pending && now.Sub(lastChange) >= watcher.Debounce {
+				pending = false
+				watcher.run()
+			}
+		}
+	}
+}
+
+// watch the input and every file it references, then run the transformer pipeline
+func (watcher *Watcher) run() {
+	watcher.files = make(map[string]fileState)
+	for _, path := range append([]string{watcher.Transformer.Input}, watcher.referencedFiles()...) {
+		watcher.files[path] = statFile(path)
+	}
+	err := watcher.runSafely()
+
+	if watcher.OnRun != nil {
+		watcher.OnRun(err)
+		return
+	}
+	if err != nil {
+		log.Printf("%s: %v", watcher.Transformer.Input, err)
+		return
+	}
+	for _, path := range watcher.Transformer.WrittenPaths {
+		fmt.Printf("%s\n", path)
+	}
+}
+
+// run the transformer once, a panic on a malformed edit fails the run instead of the watcher
+func (watcher *Watcher) runSafely() (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("unexpected error converting the spec: %v", recovered)
+		}
+	}()
+	if watcher.DryRun {
+		return watcher.Transformer.Render()
+	}
+	return watcher.Transformer.Run()
+}
+
+// whether a watched file changed since the last run
+func (watcher *Watcher) changed() bool {
+	changed := false
+	for path, state := range watcher.files {
+		if current := statFile(path); !current.equal(state) {
+			watcher.files[path] = current
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (state fileState) equal(other fileState) bool {
+	return state.exists == other.exists && state.size == other.size && state.modTime.Equal(other.modTime)
+}
+
+// local files referenced by the input, directly or through other referenced json files
+func (watcher *Watcher) referencedFiles() []string {
+	seen := map[string]bool{watcher.Transformer.Input: true}
+	queue := []string{watcher.Transformer.Input}
+	files := make([]string, 0)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		content, err := ioutil.ReadFile(current)
+		if err != nil {
+			continue
+		}
+		jsonContent, err := DecodeSpec(string(content))
+		if err != nil {
+			continue
+		}
+		for _, ref := range ExternalRefs(jsonContent) {
+			path := filepath.Join(filepath.Dir(current), ref)
+			if !seen[path] {
+				seen[path] = true
+				files = append(files, path)
+				queue = append(queue, path)
+			}
+		}
+	}
+	return files
+}
+
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size(), exists: true}
+}
+
+// relative file paths of the external $refs of a json document, web urls are ignored
+func ExternalRefs(jsonContent string) []string {
+	var document interface{}
+	if err := json.Unmarshal([]byte(jsonContent), &document); err != nil {
+		return nil
+	}
+	refs := make(map[string]bool)
+	collectExternalRefs(document, refs)
+	return sortedBoolKeys(refs)
+}
+
+func collectExternalRefs(value interface{}, refs map[string]bool) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			ref, ok := item.(string)
+			if !ok || key != "$ref" {
+				collectExternalRefs(item, refs)
+				continue
+			}
+			if index := strings.Index(ref, "#"); index >= 0 {
+				ref = ref[:index]
+			}
+			if ref != "" && !strings.Contains(ref, "://") {
+				refs[ref] = true
+			}
+		}
+	case []interface{}:
+		for _, item := range typed {
+			collectExternalRefs(item, refs)
+		}
+	}
+}
+
+// factory for Watcher with the default interval and debounce
+func NewWatcher(transformer *Transformer) *Watcher {
+	return &Watcher{Transformer: transformer, Interval: DEFAULT_WATCH_INTERVAL, Debounce: DEFAULT_WATCH_DEBOUNCE}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// test ExternalRefs
+func TestExternalRefs(t *testing.T) {
+	t.Log("Test watch - ExternalRefs")
+	{
+		content := `{"a": {"$ref": "#/components/schemas/Pet"}, "b": [{"$ref": "common.json#/Error"}],
+			"c": {"$ref": "https://example.com/spec.json"}, "d": {"$ref": "schemas/pet.json"}}`
+		refs := ExternalRefs(content)
+		expected := []string{"common.json", "schemas/pet.json"}
+		if !reflect.DeepEqual(refs, expected) {
+			t.Errorf("expected %v, got %v", expected, refs)
+		}
+	}
+}
+
+// test Watch in Watcher
+func TestWatcher_Watch(t *testing.T) {
+	t.Log("Test watcher - regenerate the doc when a referenced file changes")
+	{
+		specDir := t.TempDir()
+		input := filepath.Join(specDir, "petstore.json")
+		referenced := filepath.Join(specDir, "common.json")
+		spec := strings.Replace(readTestSpec(t), `"A sample API for <pets> & owners"`, `"Watched"`, 1)
+		spec = strings.Replace(spec, `"paths": {`, `"x-common": {"$ref": "common.json"}, "paths": {`, 1)
+		if err := ioutil.WriteFile(input, []byte(spec), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(referenced, []byte(`{}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		output := filepath.Join(t.TempDir(), "api.md")
+		watcher := NewWatcher(NewTransformer(input, output, LOCAL_SOURCE, ENGLISH, MARKDOWN_FORMAT))
+		watcher.Interval, watcher.Debounce = 10*time.Millisecond, 20*time.Millisecond
+		runs := make(chan []string, 10)
+		watcher.OnRun = func(err error) {
+			if err != nil {
+				t.Error(err)
+			}
+			runs <- watcher.Transformer.WrittenPaths
+		}
+		stop := make(chan struct{})
+		defer close(stop)
+		go watcher.Watch(stop)
+
+		if written := <-runs; len(written) != 1 {
+			t.Fatalf("expected the first run to write the doc, got %v", written)
+		}
+
+		// a change of the referenced file reruns the pipeline, the unchanged doc is not rewritten
+		if err := ioutil.WriteFile(referenced, []byte(`{"changed": true}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case written := <-runs:
+			if len(written) != 0 {
+				t.Errorf("the unchanged doc should not be rewritten, got %v", written)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("the change was not detected")
+		}
+	}
+
+	t.Log("Test watcher - keep watching after a malformed edit")
+	{
+		input := filepath.Join(t.TempDir(), "petstore.json")
+		malformed := `{"openapi": "3.0.0", "info": {"title": "Watched", "version": "1.0.0"},
+			"paths": {"/pets": {"get": {"operationId": "listPets"}}}}`
+		if err := ioutil.WriteFile(input, []byte(malformed), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		output := filepath.Join(t.TempDir(), "api.md")
+		watcher := NewWatcher(NewTransformer(input, output, LOCAL_SOURCE, ENGLISH, MARKDOWN_FORMAT))
+		watcher.Interval, watcher.Debounce = 10*time.Millisecond, 20*time.Millisecond
+		runs := make(chan error, 10)
+		watcher.OnRun = func(err error) {
+			runs <- err
+		}
+		stop := make(chan struct{})
+		defer close(stop)
+		go watcher.Watch(stop)
+		<-runs
+
+		// the watcher survives the malformed spec and reruns on the next edit
+		if err := ioutil.WriteFile(input, []byte(readTestSpec(t)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case err := <-runs:
+			if err != nil {
+				t.Errorf("expected the fixed spec to convert, got %v", err)
+			}
+			if content, err := ioutil.ReadFile(output); err != nil || !strings.Contains(string(content), "Swagger Petstore") {
+				t.Errorf("expected the doc of the fixed spec, got %q, %v", content, err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("the edit after the malformed spec was not detected")
+		}
+	}
+}
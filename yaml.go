@@ -0,0 +1,559 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	yamlIntegerPattern = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlFloatPattern   = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+// line of a yaml document, its indentation counted in spaces
+type yamlLine struct {
+	number int
+	indent int
+	text   string // content without the indentation, comments are kept for block scalars
+}
+
+// YamlParser struct, converting the block and flow styles of yaml used by specs into json values.
+// Anchors, aliases, tags and multiple documents are not supported
+type YamlParser struct {
+	lines    []yamlLine
+	position int
+}
+
+// parse a yaml document into the values encoding/json produces
+func (parser *YamlParser) Parse() (interface{}, error) {
+	parser.skipBlankLines()
+	if parser.position >= len(parser.lines) {
+		return nil, nil
+	}
+	value, err := parser.parseBlock(parser.lines[parser.position].indent)
+	if err != nil {
+		return nil, err
+	}
+	if parser.skipBlankLines(); parser.position < len(parser.lines) {
+		return nil, parser.errorf(parser.lines[parser.position], "unexpected content")
+	}
+	return value, nil
+}
+
+// parse the mapping, sequence or scalar starting at the current line, which is indented by indent
+func (parser *YamlParser) parseBlock(indent int) (interface{}, error) {
+	line := parser.lines[parser.position]
+	text := stripYamlComment(line.text)
+	switch {
+	case text == "-" || strings.HasPrefix(text, "- "):
+		return parser.parseSequence(indent)
+	case findYamlKey(text) >= 0:
+		return parser.parseMapping(indent)
+	default:
+		parser.position++
+		return parser.parseScalar(line, text, indent)
+	}
+}
+
+// parse the entries of a block sequence indented by indent
+func (parser *YamlParser) parseSequence(indent int) ([]interface{}, error) {
+	items := make([]interface{}, 0)
+	for parser.skipBlankLines(); parser.position < len(parser.lines); parser.skipBlankLines() {
+		line := parser.lines[parser.position]
+		text := stripYamlComment(line.text)
+		if line.indent != indent || (text != "-" && !strings.HasPrefix(text, "- ")) {
+			if line.indent > indent {
+				return nil, parser.errorf(line, "bad indentation of a sequence entry")
+			}
+			break
+		}
+
+		rest := strings.TrimLeft(strings.TrimPrefix(text, "-"), " ")
+		if rest == "" {
+			parser.position++
+			item, err := parser.parseNested(indent, true)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			continue
+		}
+		// the entry continues on the same line, parsed as a block indented after the dash
+		offset := len(line.text) - len(strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " "))
+		parser.lines[parser.position] = yamlLine{number: line.number, indent: indent + offset,
+			text: line.text[offset:]}
+		item, err := parser.parseBlock(indent + offset)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// parse the entries of a block mapping indented by indent
+func (parser *YamlParser) parseMapping(indent int) (map[string]interface{}, error) {
+	object := make(map[string]interface{})
+	for parser.skipBlankLines(); parser.position < len(parser.lines); parser.skipBlankLines() {
+		line := parser.lines[parser.position]
+		if line.indent < indent {
+			break
+		}
+		text := stripYamlComment(line.text)
+		if line.indent == indent && (text == "-" || strings.HasPrefix(text, "- ")) {
+			break
+		}
+		separator := findYamlKey(text)
+		if line.indent > indent || separator < 0 {
+			return nil, parser.errorf(line, "expected a mapping key")
+		}
+		key, err := parseYamlKey(text[:separator])
+		if err != nil {
+			return nil, parser.errorf(line, "%v", err)
+		}
+		if _, ok := object[key]; ok {
+			return nil, parser.errorf(line, "duplicate key %q", key)
+		}
+		parser.position++
+
+		rest := strings.TrimSpace(text[separator+1:])
+		var value interface{}
+		switch {
+		case rest == "":
+			value, err = parser.parseNested(indent, false)
+		case rest[0] == '|' || rest[0] == '>':
+			value, err = parser.parseBlockScalar(line, rest, indent)
+		default:
+			value, err = parser.parseScalar(line, rest, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		object[key] = value
+	}
+	return object, nil
+}
+
+// parse the value of an entry continued on the next lines, a mapping value may be a sequence of the same indent
+func (parser *YamlParser) parseNested(indent int, inSequence bool) (interface{}, error) {
+	parser.skipBlankLines()
+	if parser.position >= len(parser.lines) {
+		return nil, nil
+	}
+	line := parser.lines[parser.position]
+	text := stripYamlComment(line.text)
+	if line.indent > indent || (!inSequence && line.indent == indent && strings.HasPrefix(text, "- ")) {
+		return parser.parseBlock(line.indent)
+	}
+	return nil, nil
+}
+
+// parse a flow collection, a quoted scalar or a plain scalar continued on the lines indented more than indent
+func (parser *YamlParser) parseScalar(line yamlLine, text string, indent int) (interface{}, error) {
+	if text[0] == '[' || text[0] == '{' {
+		// a flow collection ends on the line its brackets are balanced
+		for !yamlFlowClosed(text) && parser.position < len(parser.lines) {
+			text += " " + stripYamlComment(parser.lines[parser.position].text)
+			parser.position++
+		}
+		flow := &yamlFlowParser{text: text}
+		value, err := flow.parseValue()
+		if err == nil {
+			if flow.skipSpaces(); flow.position < len(flow.text) {
+				err = fmt.Errorf("unexpected %q after a flow collection", flow.text[flow.position:])
+			}
+		}
+		if err != nil {
+			return nil, parser.errorf(line, "%v", err)
+		}
+		return value, nil
+	}
+	if text[0] == '"' || text[0] == '\'' {
+		if yamlQuoteEnd(text, 0) < 0 {
+			text = parser.foldQuotedScalar(text)
+		}
+		value, err := unquoteYaml(text)
+		if err != nil {
+			return nil, parser.errorf(line, "%v", err)
+		}
+		return value, nil
+	}
+	if findYamlKey(text) >= 0 {
+		return nil, parser.errorf(line, "mapping values are not allowed in a plain scalar")
+	}
+
+	// a plain scalar is folded with the lines indented more than its entry
+	for parser.position < len(parser.lines) {
+		next := parser.lines[parser.position]
+		nextText := stripYamlComment(next.text)
+		if next.indent <= indent || nextText == "" {
+			break
+		}
+		if findYamlKey(nextText) >= 0 {
+			return nil, parser.errorf(next, "bad indentation of a mapping entry")
+		}
+		text += " " + nextText
+		parser.position++
+	}
+	return resolveYamlScalar(text), nil
+}
+
+// fold the lines of a quoted scalar until its closing quote, a line break becomes a space and every blank line a
+// line feed, an escaped line break of a double quoted scalar joins the lines
+func (parser *YamlParser) foldQuotedScalar(text string) string {
+	double, blank := text[0] == '"', 0
+	for parser.position < len(parser.lines) && yamlQuoteEnd(text, 0) < 0 {
+		next := parser.lines[parser.position]
+		parser.position++
+		if next.text == "" {
+			blank++
+			continue
+		}
+		trailing := len(text) - len(strings.TrimRight(text, "\\"))
+		switch {
+		case blank > 0 && double:
+			text += strings.Repeat("\\n", blank)
+		case blank > 0:
+			text += strings.Repeat("\n", blank)
+		case double && trailing%2 == 1:
+			text = text[:len(text)-1]
+		default:
+			text += " "
+		}
+		text += next.text
+		blank = 0
+	}
+	return text
+}
+
+// parse a literal | or folded > block scalar, with its - and + chomping indicators
+func (parser *YamlParser) parseBlockScalar(line yamlLine, header string, indent int) (string, error) {
+	header = stripYamlComment(header)
+	folded, chomping := header[0] == '>', ""
+	for _, indicator := range header[1:] {
+		switch {
+		case indicator == '-' || indicator == '+':
+			chomping = string(indicator)
+		case indicator < '1' || indicator > '9':
+			return "", parser.errorf(line, "invalid block scalar header %q", header)
+		}
+	}
+
+	lines := make([]string, 0)
+	blockIndent := -1
+	for ; parser.position < len(parser.lines); parser.position++ {
+		next := parser.lines[parser.position]
+		if next.text == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if next.indent <= indent {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = next.indent
+		}
+		lines = append(lines, strings.Repeat(" ", next.indent-blockIndent)+next.text)
+	}
+	trailing := 0
+	for trailing < len(lines) && lines[len(lines)-1-trailing] == "" {
+		trailing++
+	}
+	lines = lines[:len(lines)-trailing]
+
+	// folded lines are joined with spaces, except around blank and more indented lines
+	content := ""
+	for index, text := range lines {
+		if index > 0 {
+			previous := lines[index-1]
+			switch {
+			case !folded || text == "" || strings.HasPrefix(text, " ") || strings.HasPrefix(previous, " "):
+				content += "\n"
+			case previous == "":
+			default:
+				content += " "
+			}
+		}
+		content += text
+	}
+	switch {
+	case len(lines) == 0 || chomping == "-":
+	case chomping == "+":
+		content += strings.Repeat("\n", trailing+1)
+	default:
+		content += "\n"
+	}
+	return content, nil
+}
+
+// move to the next line holding content
+func (parser *YamlParser) skipBlankLines() {
+	for parser.position < len(parser.lines) && stripYamlComment(parser.lines[parser.position].text) == "" {
+		parser.position++
+	}
+}
+
+// error at a line of the document
+func (parser *YamlParser) errorf(line yamlLine, format string, arguments ...interface{}) error {
+	return fmt.Errorf("yaml line %d: %s", line.number, fmt.Sprintf(format, arguments...))
+}
+
+// yamlFlowParser struct, parsing the [a, b] and {a: b} flow collections of yaml
+type yamlFlowParser struct {
+	text     string
+	position int
+}
+
+// parse a flow collection or a scalar inside a flow collection
+func (flow *yamlFlowParser) parseValue() (interface{}, error) {
+	flow.skipSpaces()
+	if flow.position >= len(flow.text) {
+		return nil, fmt.Errorf("unterminated flow collection")
+	}
+	switch flow.text[flow.position] {
+	case '[':
+		flow.position++
+		items := make([]interface{}, 0)
+		for flow.skipSpaces(); !flow.consume(']'); flow.skipSpaces() {
+			item, err := flow.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			if flow.skipSpaces(); !flow.consume(',') && !flow.peek(']') {
+				return nil, fmt.Errorf("expected , or ] in a flow sequence")
+			}
+		}
+		return items, nil
+	case '{':
+		flow.position++
+		object := make(map[string]interface{})
+		for flow.skipSpaces(); !flow.consume('}'); flow.skipSpaces() {
+			key, err := flow.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			var value interface{}
+			if flow.skipSpaces(); flow.consume(':') {
+				if value, err = flow.parseValue(); err != nil {
+					return nil, err
+				}
+			}
+			object[fmt.Sprintf("%v", key)] = value
+			if flow.skipSpaces(); !flow.consume(',') && !flow.peek('}') {
+				return nil, fmt.Errorf("expected , or } in a flow mapping")
+			}
+		}
+		return object, nil
+	case '"', '\'':
+		end := yamlQuoteEnd(flow.text, flow.position)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated quoted scalar")
+		}
+		value, err := unquoteYaml(flow.text[flow.position : end+1])
+		flow.position = end + 1
+		return value, err
+	default:
+		start := flow.position
+		for flow.position < len(flow.text) && !strings.ContainsRune(",]}", rune(flow.text[flow.position])) &&
+			!(flow.text[flow.position] == ':' && (flow.position+1 == len(flow.text) ||
+				strings.ContainsRune(" ,]}", rune(flow.text[flow.position+1])))) {
+			flow.position++
+		}
+		return resolveYamlScalar(strings.TrimSpace(flow.text[start:flow.position])), nil
+	}
+}
+
+// skip the spaces between the tokens of a flow collection
+func (flow *yamlFlowParser) skipSpaces() {
+	for flow.position < len(flow.text) && flow.text[flow.position] == ' ' {
+		flow.position++
+	}
+}
+
+// whether the next character is c, without consuming it
+func (flow *yamlFlowParser) peek(c byte) bool {
+	return flow.position < len(flow.text) && flow.text[flow.position] == c
+}
+
+// consume the next character when it is c
+func (flow *yamlFlowParser) consume(c byte) bool {
+	if flow.peek(c) {
+		flow.position++
+		return true
+	}
+	return false
+}
+
+// resolve a plain scalar as null, a boolean, a number or a string, following the yaml core schema
+func resolveYamlScalar(text string) interface{} {
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	}
+	if yamlIntegerPattern.MatchString(text) {
+		if integer, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return json.Number(strconv.FormatInt(integer, 10))
+		}
+	}
+	if yamlFloatPattern.MatchString(text) {
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			return number
+		}
+	}
+	return text
+}
+
+// unquote a single or double quoted scalar
+func unquoteYaml(text string) (string, error) {
+	end := yamlQuoteEnd(text, 0)
+	if end < 0 {
+		return "", fmt.Errorf("unterminated quoted scalar")
+	}
+	if strings.TrimSpace(text[end+1:]) != "" {
+		return "", fmt.Errorf("unexpected %q after a quoted scalar", text[end+1:])
+	}
+	if text[0] == '\'' {
+		return strings.Replace(text[1:end], "''", "'", -1), nil
+	}
+	value, err := strconv.Unquote(text[:end+1])
+	if err != nil {
+		// escapes yaml shares with json but not with go, such as \/
+		var decoded string
+		if json.Unmarshal([]byte(text[:end+1]), &decoded) != nil {
+			return "", fmt.Errorf("invalid escape in %s", text[:end+1])
+		}
+		return decoded, nil
+	}
+	return value, nil
+}
+
+// parse a mapping key, plain or quoted
+func parseYamlKey(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text != "" && (text[0] == '"' || text[0] == '\'') {
+		return unquoteYaml(text)
+	}
+	return text, nil
+}
+
+// index of the colon separating a mapping key from its value, -1 when the text is not a mapping entry
+func findYamlKey(text string) int {
+	if text == "" || text == "-" || strings.HasPrefix(text, "- ") || strings.ContainsRune("[{|>", rune(text[0])) {
+		return -1
+	}
+	start := 0
+	if text[0] == '"' || text[0] == '\'' {
+		if start = yamlQuoteEnd(text, 0); start < 0 {
+			return -1
+		}
+	}
+	for index := start; index < len(text); index++ {
+		if text[index] == ':' && (index+1 == len(text) || text[index+1] == ' ') {
+			return index
+		}
+	}
+	return -1
+}
+
+// index of the quote closing the quoted scalar starting at start, -1 when it is not closed
+func yamlQuoteEnd(text string, start int) int {
+	quote := text[start]
+	for index := start + 1; index < len(text); index++ {
+		switch {
+		case quote == '"' && text[index] == '\\':
+			index++
+		case text[index] == quote && quote == '\'' && index+1 < len(text) && text[index+1] == '\'':
+			index++
+		case text[index] == quote:
+			return index
+		}
+	}
+	return -1
+}
+
+// whether the brackets of a flow collection are balanced, ignoring the ones of quoted scalars
+func yamlFlowClosed(text string) bool {
+	depth := 0
+	for index := 0; index < len(text); index++ {
+		switch text[index] {
+		case '"', '\'':
+			if end := yamlQuoteEnd(text, index); end >= 0 {
+				index = end
+			}
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// remove the comment of a line, a # at the start of the line or after a space outside quoted scalars
+func stripYamlComment(text string) string {
+	for index := 0; index < len(text); index++ {
+		switch {
+		case (text[index] == '"' || text[index] == '\'') && (index == 0 || strings.ContainsRune(" [{,:", rune(text[index-1]))):
+			end := yamlQuoteEnd(text, index)
+			if end < 0 {
+				// the quoted scalar continues on the next lines
+				return strings.TrimSpace(text)
+			}
+			index = end
+		case text[index] == '#' && (index == 0 || text[index-1] == ' '):
+			return strings.TrimSpace(text[:index])
+		}
+	}
+	return strings.TrimSpace(text)
+}
+
+// convert a yaml spec into json, so it is analyzed the same way as a json spec
+func YamlToJson(content string) (string, error) {
+	value, err := NewYamlParser(content).Parse()
+	if err != nil {
+		return "", err
+	}
+	jsonContent, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonContent), nil
+}
+
+// decode a json or yaml spec into json, the single step every spec read by the tool goes through
+func DecodeSpec(content string) (string, error) {
+	if strings.TrimSpace(content) == "" || !IsYamlContent(content) {
+		return content, nil
+	}
+	return YamlToJson(content)
+}
+
+// whether a spec is written in yaml, json specs being objects
+func IsYamlContent(content string) bool {
+	return !strings.HasPrefix(strings.TrimSpace(content), "{")
+}
+
+// factory for YamlParser, the document start and directive lines are skipped
+func NewYamlParser(content string) *YamlParser {
+	parser := &YamlParser{}
+	for index, text := range strings.Split(strings.Replace(content, "\r\n", "\n", -1), "\n") {
+		trimmed := strings.TrimLeft(text, " ")
+		if strings.HasPrefix(text, "%") || text == "---" || strings.HasPrefix(text, "--- ") {
+			trimmed = ""
+		}
+		if strings.TrimSpace(trimmed) == "" {
+			trimmed = ""
+		}
+		parser.lines = append(parser.lines, yamlLine{number: index + 1, indent: len(text) - len(trimmed),
+			text: strings.TrimRight(trimmed, " \t")})
+	}
+	return parser
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// test YamlToJson with the block and flow styles of specs
+func TestYamlToJson(t *testing.T) {
+	t.Log("Test yaml - block and flow styles")
+	{
+		content := `%YAML 1.2
+---
+openapi: "3.0.0"   # quoted to stay a string
+info:
+  title: It's a 'pet' store
+  version: 1.0.0
+  description: |
+    First line,
+      indented line.
+
+    Last line.
+  summary: >-
+    folded
+    lines
+
+    kept apart
+tags: [pets, {name: store, x-order: 2}]
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+          enum:
+            - 1
+            - -2.5
+      - {name: "tab\there", in: query}
+      responses:
+        '200':
+          description: plain text
+            folded on the next line
+        default: ~
+`
+		jsonContent, err := YamlToJson(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actual interface{}
+		if err := json.Unmarshal([]byte(jsonContent), &actual); err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]interface{}{
+			"openapi": "3.0.0",
+			"info": map[string]interface{}{"title": "It's a 'pet' store", "version": "1.0.0",
+				"description": "First line,\n  indented line.\n\nLast line.\n", "summary": "folded lines\nkept apart"},
+			"tags": []interface{}{"pets", map[string]interface{}{"name": "store", "x-order": float64(2)}},
+			"paths": map[string]interface{}{"/pets/{id}": map[string]interface{}{"get": map[string]interface{}{
+				"parameters": []interface{}{
+					map[string]interface{}{"name": "id", "in": "path", "required": true, "schema": map[string]interface{}{
+						"type": "integer", "enum": []interface{}{float64(1), -2.5}}},
+					map[string]interface{}{"name": "tab\there", "in": "query"},
+				},
+				"responses": map[string]interface{}{
+					"200":     map[string]interface{}{"description": "plain text folded on the next line"},
+					"default": nil,
+				},
+			}}},
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected %v, got %v", expected, actual)
+		}
+	}
+
+	t.Log("Test yaml - malformed documents")
+	{
+		for _, content := range []string{"a: [1, 2", "a: 1\na: 2", "a: 1\n  b: 2\n c: 3", "a: 'open", "a: b: c",
+			"- a: b: c", "a: \"open\nb: 1"} {
+			if _, err := YamlToJson(content); err == nil {
+				t.Errorf("expected an error for %q", content)
+			}
+		}
+	}
+
+	t.Log("Test yaml - multi-line quoted scalars")
+	{
+		content := "a: \"first line,\n  second # line\n\n  after a blank line\"\n" +
+			"b: 'it''s\n  folded'\n" +
+			"c: \"joined \\\n  line\"\n" +
+			"d: 1\n"
+		jsonContent, err := YamlToJson(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actual interface{}
+		if err := json.Unmarshal([]byte(jsonContent), &actual); err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]interface{}{"a": "first line, second # line\nafter a blank line",
+			"b": "it's folded", "c": "joined line", "d": float64(1)}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected %v, got %v", expected, actual)
+		}
+	}
+}
+
+// test DecodeSpec
+func TestDecodeSpec(t *testing.T) {
+	t.Log("Test yaml - DecodeSpec keeps json and converts yaml")
+	{
+		cases := map[string]string{
+			`{"openapi": "3.0.0"}`: `{"openapi": "3.0.0"}`,
+			"openapi: 3.0.0\n":     `{"openapi":"3.0.0"}`,
+			"":                     "",
+		}
+		for content, expected := range cases {
+			if actual, err := DecodeSpec(content); err != nil || actual != expected {
+				t.Errorf("%q: expected %q, got %q, %v", content, expected, actual, err)
+			}
+		}
+	}
+
+	t.Log("Test yaml - a local yaml spec is read as json")
+	{
+		input := filepath.Join(t.TempDir(), "openapi.yaml")
+		if err := ioutil.WriteFile(input, []byte("openapi: 3.0.0\npaths: {}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		content, err := NewSwaggerContentGetter(input, LOCAL_SOURCE).GetContent()
+		if expected := `{"openapi":"3.0.0","paths":{}}`; err != nil || content != expected {
+			t.Errorf("expected %q, got %q, %v", expected, content, err)
+		}
+	}
+}